@@ -24,6 +24,20 @@ struct Response {
     stats: Stats,
     risk_score: i32,
     version: String,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    warnings: Vec<Warning>,
+    #[serde(default)]
+    partial: bool,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    incomplete_detectors: Vec<String>,
+}
+
+#[derive(Debug, Serialize, Deserialize)]
+struct Warning {
+    code: String,
+    #[serde(default)]
+    field: String,
+    message: String,
 }
 
 #[derive(Debug, Serialize, Deserialize)]
@@ -32,11 +46,45 @@ struct Finding {
     type_: String,
     start: usize,
     end: usize,
+    #[serde(default)]
+    rune_start: usize,
+    #[serde(default)]
+    rune_end: usize,
+    #[serde(default)]
+    utf16_start: usize,
+    #[serde(default)]
+    utf16_end: usize,
+    #[serde(default)]
+    line: usize,
+    #[serde(default)]
+    column: usize,
+    #[serde(default)]
+    end_line: usize,
+    #[serde(default)]
+    end_column: usize,
     confidence: f64,
     risk: i32,
     replacement: String,
     replacement_preview: String,
     reason: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    valid: Option<bool>,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    network: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    precision: Option<u32>,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    ip_class: String,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    country_code: String,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    line_type: String,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    rule_id: String,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    document_type: String,
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    region: String,
 }
 
 #[derive(Debug, Serialize, Deserialize)]
@@ -9,13 +9,30 @@ export interface Config {
 
 export interface Finding {
   type: string;
-  start: number;
+  start: number; // UTF-8 字节偏移
   end: number;
+  rune_start?: number;
+  rune_end?: number;
+  utf16_start?: number; // 与 JavaScript 字符串下标一致
+  utf16_end?: number;
+  line?: number;
+  column?: number;
+  end_line?: number;
+  end_column?: number;
   confidence: number;
   risk: number;
   replacement: string;
   replacement_preview: string;
   reason: string;
+  valid?: boolean; // 是否通过结构校验（如身份证校验位）
+  network?: string; // 卡组织（仅银行卡和信用卡）
+  precision?: number; // 坐标精度（度的小数位数，仅 GPS 坐标）
+  ip_class?: string; // 地址分类（仅 IP 地址）
+  country_code?: string; // 国际电话区号（仅电话号码）
+  line_type?: string; // 号码类型（仅电话号码）
+  rule_id?: string; // 命中的规则 ID（仅 Token/Key）
+  document_type?: string; // 证件子类型（仅身份证、护照和境外证件号码）
+  region?: string; // 签发证件的国家或地区（ISO 3166-1）
 }
 
 export interface Stats {
@@ -32,6 +49,15 @@ export interface Response {
   stats: Stats;
   risk_score: number;
   version: string;
+  warnings?: Warning[];
+  partial?: boolean; // 处理超时，只包含已完成检测器的结果
+  incomplete_detectors?: string[];
+}
+
+export interface Warning {
+  code: string;
+  field?: string;
+  message: string;
 }
 
 export interface TextBoundingBox {
@@ -49,4 +75,9 @@ export interface ImageFinding {
   bbox: TextBoundingBox;
   confidence: number;
   risk: number;
-}
\ No newline at end of file
+}
+// findingRange 返回 finding 在 JavaScript 字符串中的下标范围
+// 旧版本引擎没有 utf16_start/utf16_end 时回退到字节偏移
+export function findingRange(finding: Finding): [number, number] {
+  return [finding.utf16_start ?? finding.start, finding.utf16_end ?? finding.end];
+}
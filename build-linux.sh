@@ -11,7 +11,7 @@ echo "=========================================="
 echo ""
 echo "步骤 1/3: 构建 Go 引擎 (Linux)..."
 cd engine/go
-GOOS=linux GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+GOOS=linux GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
 echo "✓ Go 引擎构建完成"
 
 # 安装前端依赖
@@ -23,7 +23,7 @@ fi
 echo ""
 echo "步骤 1/3: 构建 Go 引擎 (macOS $MACOS_ARCH)..."
 cd engine/go
-GOOS=darwin GOARCH=$GOARCH go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+GOOS=darwin GOARCH=$GOARCH go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
 echo "✓ Go 引擎构建完成"
 
 # 安装前端依赖
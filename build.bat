@@ -3,7 +3,7 @@ REM 构建脚本 - Windows
 
 echo 构建 Go 引擎...
 cd engine\go
-go build -ldflags="-H windowsgui" -o ..\..\apps\tauri\src-tauri\bin\prompt-sanitizer.exe .\cmd\main.go
+go build -ldflags="-H windowsgui" -o ..\..\apps\tauri\src-tauri\bin\prompt-sanitizer.exe .\cmd
 if errorlevel 1 (
     echo Go 引擎构建失败
     exit /b 1
@@ -38,20 +38,20 @@ cd engine/go
 
 if [ "$TARGET_PLATFORM" = "linux" ]; then
     echo "构建 Linux 版本..."
-    GOOS=linux GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+    GOOS=linux GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
 elif [ "$TARGET_PLATFORM" = "macos" ]; then
     # 检测 macOS 架构
     MACOS_ARCH=$(uname -m)
     if [ "$MACOS_ARCH" = "arm64" ]; then
         echo "构建 macOS Apple Silicon (arm64) 版本..."
-        GOOS=darwin GOARCH=arm64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+        GOOS=darwin GOARCH=arm64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
     else
         echo "构建 macOS Intel (x86_64) 版本..."
-        GOOS=darwin GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+        GOOS=darwin GOARCH=amd64 go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
     fi
 else
     echo "构建当前平台版本..."
-    go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd/main.go
+    go build -o ../../apps/tauri/src-tauri/bin/prompt-sanitizer ./cmd
 fi
 
 echo "✓ Go 引擎构建完成"
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// runBatch 逐行处理 JSONL 输入，每行输出一个响应，最后输出汇总
+func runBatch(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	inputPath := fs.String("input", "-", "输入文件路径，- 表示 stdin")
+	outputPath := fs.String("output", "-", "输出文件路径，- 表示 stdout")
+	templatePath := fs.String("template", "", "请求模板 JSON 文件，指定后每行按原始文本处理")
+	raw := fs.Bool("raw", false, "每行按原始文本处理（使用默认请求参数）")
+	configPath := fs.String("config", "", "配置文件路径")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// 原始文本模式下，每行文本套用同一个请求模板
+	var template *types.Request
+	if *templatePath != "" {
+		data, err := os.ReadFile(*templatePath)
+		if err != nil {
+			return fmt.Errorf("read template error: %w", err)
+		}
+		template = &types.Request{}
+		if err := json.Unmarshal(data, template); err != nil {
+			return fmt.Errorf("invalid template JSON: %w", err)
+		}
+	} else if *raw {
+		template = &types.Request{}
+	}
+
+	in := stdin
+	if *inputPath != "-" {
+		f, err := os.Open(*inputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	out := stdout
+	if *outputPath != "-" {
+		f, err := os.Create(*outputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	eng, err := newEngine(*configPath)
+	if err != nil {
+		return err
+	}
+	// 模板有误时每一行都会失败，提前报错
+	if template != nil {
+		resolved, _, err := eng.Resolve(template)
+		if err != nil {
+			return err
+		}
+		template = resolved
+	}
+
+	_, err = processBatch(eng, in, out, template)
+	return err
+}
+
+// processBatch 流式处理输入，单条记录失败时输出错误对象并继续处理
+// template 为 nil 时每行是一个 JSON 请求，否则每行是原始文本
+func processBatch(eng *engine.Engine, r io.Reader, w io.Writer, template *types.Request) (types.BatchSummary, error) {
+	summary := types.BatchSummary{
+		Stats: types.Stats{ByCategory: make(map[string]int)},
+	}
+
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+
+	// 每条记录输出后立即刷新，便于下游边读边处理
+	emit := func(v interface{}) error {
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}
+
+	lineNum := 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return summary, fmt.Errorf("read input error: %w", readErr)
+		}
+		if len(line) > 0 {
+			lineNum++
+			line = bytes.TrimRight(line, "\r\n")
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			summary.Records++
+			resp, err := processBatchLine(eng, line, template)
+			if err != nil {
+				summary.Failed++
+				errResp := newErrorResponse(err, types.ErrCodeProcessingError)
+				if err := emit(types.BatchError{Line: lineNum, Error: errResp.Error, Code: errResp.Code}); err != nil {
+					return summary, err
+				}
+			} else {
+				summary.Succeeded++
+				mergeStats(&summary.Stats, resp.Stats)
+				if err := emit(resp); err != nil {
+					return summary, err
+				}
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+	}
+
+	err := emit(map[string]interface{}{
+		"summary": summary,
+	})
+	return summary, err
+}
+
+// processBatchLine 解析并处理一行输入
+func processBatchLine(eng *engine.Engine, line []byte, template *types.Request) (*types.Response, error) {
+	var req types.Request
+	if template != nil {
+		req = *template
+		req.Text = string(line)
+	} else if err := json.Unmarshal(line, &req); err != nil {
+		return nil, &types.RequestError{Code: types.ErrCodeInvalidJSON, Message: fmt.Sprintf("invalid JSON: %v", err)}
+	}
+
+	if err := prepareRequest(&req); err != nil {
+		return nil, err
+	}
+
+	resp, err := processRequest(context.Background(), eng, &req)
+	if err != nil {
+		return nil, fmt.Errorf("processing error: %w", err)
+	}
+	return resp, nil
+}
+
+// mergeStats 将单条记录的统计累加到汇总中
+func mergeStats(dst *types.Stats, src types.Stats) {
+	dst.TotalFindings += src.TotalFindings
+	dst.HighRiskCount += src.HighRiskCount
+	dst.MediumRiskCount += src.MediumRiskCount
+	dst.LowRiskCount += src.LowRiskCount
+	for category, count := range src.ByCategory {
+		dst.ByCategory[category] += count
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+func TestProcessBatch(t *testing.T) {
+	input := strings.Join([]string{
+		`{"text":"我的手机号是13812345678","enabled_categories":["phone"]}`,
+		``,
+		`{"text":`,
+		`{"text":"邮箱是test@corp.io","enabled_categories":["email"]}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	summary, err := processBatch(engine.NewEngine(), strings.NewReader(input), &out, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Records != 3 || summary.Succeeded != 2 || summary.Failed != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Stats.TotalFindings != 2 || summary.Stats.ByCategory["phone"] != 1 || summary.Stats.ByCategory["email"] != 1 {
+		t.Errorf("unexpected stats: %+v", summary.Stats)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 output lines, got %d: %s", len(lines), out.String())
+	}
+	var batchErr types.BatchError
+	if err := json.Unmarshal([]byte(lines[1]), &batchErr); err != nil || batchErr.Line != 3 || batchErr.Error == "" {
+		t.Errorf("expected error object for line 3, got %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[3], `{"summary":`) {
+		t.Errorf("expected summary as last line, got %s", lines[3])
+	}
+}
+
+func TestProcessBatchTemplate(t *testing.T) {
+	template := &types.Request{Strategy: "mask", EnabledCategories: []string{"phone"}}
+	input := "请拨打13812345678\r\n没有敏感信息\n"
+
+	var out bytes.Buffer
+	summary, err := processBatch(engine.NewEngine(), strings.NewReader(input), &out, template)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Succeeded != 2 || summary.Stats.TotalFindings != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	var resp types.Response
+	firstLine := strings.SplitN(out.String(), "\n", 2)[0]
+	if err := json.Unmarshal([]byte(firstLine), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.SanitizedText != "请拨打138****5678" {
+		t.Errorf("unexpected sanitized text: %q", resp.SanitizedText)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// maxHTTPBodySize 单个 HTTP 请求体的最大长度（64MB）
+const maxHTTPBodySize = 64 * 1024 * 1024
+
+// runHTTP 启动本地 HTTP 服务，收到 SIGINT/SIGTERM 后优雅退出
+func runHTTP(args []string) error {
+	fs := flag.NewFlagSet("http", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8787", "监听地址")
+	configPath := fs.String("config", "", "配置文件路径")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	eng, err := newEngine(*configPath)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newHTTPHandler(eng),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	log.Printf("prompt-sanitizer %s listening on http://%s", engine.Version, *addr)
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// httpHandler 将引擎暴露为 REST 接口
+type httpHandler struct {
+	eng *engine.Engine
+}
+
+func newHTTPHandler(eng *engine.Engine) http.Handler {
+	h := &httpHandler{eng: eng}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sanitize", h.handleProcess("sanitize"))
+	mux.HandleFunc("/v1/annotate", h.handleProcess("annotate"))
+	mux.HandleFunc("/v1/categories", h.handleCategories)
+	mux.HandleFunc("/v1/profiles", h.handleProfiles)
+	mux.HandleFunc("/healthz", h.handleHealth)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, http.StatusNotFound, types.ErrorResponse{Error: fmt.Sprintf("not found: %s", r.URL.Path), Code: types.ErrCodeNotFound})
+	})
+	return mux
+}
+
+// handleProcess 处理清洗/标注请求，mode 由路径决定
+func (h *httpHandler) handleProcess(mode string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeHTTPError(w, http.StatusMethodNotAllowed, types.ErrorResponse{Error: fmt.Sprintf("method not allowed: %s", r.Method), Code: types.ErrCodeMethodNotAllowed})
+			return
+		}
+
+		var req types.Request
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPBodySize))
+		if err := decoder.Decode(&req); err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				writeHTTPError(w, http.StatusRequestEntityTooLarge, types.ErrorResponse{Error: fmt.Sprintf("request body too large (limit %d bytes)", maxErr.Limit), Code: types.ErrCodeRequestTooLarge})
+				return
+			}
+			writeHTTPError(w, http.StatusBadRequest, types.ErrorResponse{Error: fmt.Sprintf("invalid JSON: %v", err), Code: types.ErrCodeInvalidJSON})
+			return
+		}
+
+		req.Mode = mode
+		if err := prepareRequest(&req); err != nil {
+			writeHTTPError(w, http.StatusBadRequest, newErrorResponse(err, types.ErrCodeInvalidJSON))
+			return
+		}
+
+		// 客户端断开连接时停止处理
+		resp, err := processRequest(r.Context(), h.eng, &req)
+		if err != nil {
+			// 请求校验失败属于客户端错误
+			status := http.StatusInternalServerError
+			var reqErr *types.RequestError
+			if errors.As(err, &reqErr) {
+				status = http.StatusBadRequest
+				if reqErr.Code == types.ErrCodeInputTooLarge {
+					status = http.StatusRequestEntityTooLarge
+				}
+			}
+			writeHTTPError(w, status, newErrorResponse(fmt.Errorf("processing error: %w", err), types.ErrCodeProcessingError))
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func (h *httpHandler) handleCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeHTTPError(w, http.StatusMethodNotAllowed, types.ErrorResponse{Error: fmt.Sprintf("method not allowed: %s", r.Method), Code: types.ErrCodeMethodNotAllowed})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"categories": h.eng.Categories(),
+	})
+}
+
+func (h *httpHandler) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeHTTPError(w, http.StatusMethodNotAllowed, types.ErrorResponse{Error: fmt.Sprintf("method not allowed: %s", r.Method), Code: types.ErrCodeMethodNotAllowed})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"profiles": h.eng.Profiles(),
+	})
+}
+
+func (h *httpHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeHTTPError(w, http.StatusMethodNotAllowed, types.ErrorResponse{Error: fmt.Sprintf("method not allowed: %s", r.Method), Code: types.ErrCodeMethodNotAllowed})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  "ok",
+		"version": engine.Version,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeHTTPError 输出与 respondError 相同结构的错误 JSON
+func writeHTTPError(w http.ResponseWriter, status int, errResp types.ErrorResponse) {
+	writeJSON(w, status, errResp)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	handler := newHTTPHandler(engine.NewEngine())
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"清洗", http.MethodPost, "/v1/sanitize", `{"text":"我的手机号是13812345678"}`, http.StatusOK},
+		{"标注", http.MethodPost, "/v1/annotate", `{"text":"我的手机号是13812345678"}`, http.StatusOK},
+		{"类别列表", http.MethodGet, "/v1/categories", "", http.StatusOK},
+		{"健康检查", http.MethodGet, "/healthz", "", http.StatusOK},
+		{"无效JSON", http.MethodPost, "/v1/sanitize", `{"text":`, http.StatusBadRequest},
+		{"缺少text", http.MethodPost, "/v1/sanitize", `{}`, http.StatusBadRequest},
+		{"未知策略", http.MethodPost, "/v1/sanitize", `{"text":"a","strategy":"mask2"}`, http.StatusBadRequest},
+		{"方法错误", http.MethodGet, "/v1/sanitize", "", http.StatusMethodNotAllowed},
+		{"未知路径", http.MethodGet, "/v2/unknown", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				var errResp types.ErrorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &errResp); err != nil || errResp.Error == "" || errResp.Code == "" {
+					t.Errorf("expected JSON error body with code, got %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPAnnotateKeepsText(t *testing.T) {
+	handler := newHTTPHandler(engine.NewEngine())
+	body := `{"text":"我的手机号是13812345678","mode":"sanitize"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/annotate", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp types.Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.SanitizedText != "我的手机号是13812345678" {
+		t.Errorf("annotate should not modify text, got %q", resp.SanitizedText)
+	}
+	if len(resp.Findings) != 1 {
+		t.Errorf("expected 1 finding, got %d", len(resp.Findings))
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"github.com/prompt-sanitizer/engine/internal/config"
 	"github.com/prompt-sanitizer/engine/internal/engine"
 	"github.com/prompt-sanitizer/engine/pkg/types"
 	"io"
@@ -11,11 +15,47 @@ import (
 )
 
 func main() {
+	// 子命令分发，未指定时保持一次性 stdin/stdout 模式
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "--serve", "serve":
+			if err := runServe(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+				respondError(newErrorResponse(fmt.Errorf("serve error: %w", err), types.ErrCodeInternalError))
+			}
+			return
+		case "http":
+			if err := runHTTP(os.Args[2:]); err != nil {
+				respondError(newErrorResponse(fmt.Errorf("http error: %w", err), types.ErrCodeInternalError))
+			}
+			return
+		case "batch":
+			if err := runBatch(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+				respondError(newErrorResponse(fmt.Errorf("batch error: %w", err), types.ErrCodeInternalError))
+			}
+			return
+		case "scan", "sanitize":
+			if err := runScan(os.Args[2:], os.Stdout); err != nil {
+				respondError(newErrorResponse(fmt.Errorf("scan error: %w", err), types.ErrCodeInternalError))
+			}
+			return
+		case "stream":
+			if err := runStream(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+				respondError(newErrorResponse(fmt.Errorf("stream error: %w", err), types.ErrCodeInternalError))
+			}
+			return
+		}
+	}
+
+	runOnce()
+}
+
+// runOnce 从 stdin 读取一个请求，输出一个响应后退出
+func runOnce() {
 	// 从 stdin 读取 JSON 请求
 	// 使用 io.ReadAll 一次性读取所有内容
 	requestJSONBytes, err := io.ReadAll(os.Stdin)
 	if err != nil {
-		respondError(fmt.Sprintf("read stdin error: %v", err))
+		respondError(types.ErrorResponse{Error: fmt.Sprintf("read stdin error: %v", err), Code: types.ErrCodeInternalError})
 		return
 	}
 
@@ -23,59 +63,136 @@ func main() {
 	requestJSON := strings.TrimSpace(string(requestJSONBytes))
 
 	if requestJSON == "" {
-		respondError("no input received")
+		respondError(types.ErrorResponse{Error: "no input received", Code: types.ErrCodeInvalidJSON})
 		return
 	}
 
 	// 解析请求
 	var req types.Request
 	if err := json.Unmarshal([]byte(requestJSON), &req); err != nil {
-		respondError(fmt.Sprintf("invalid JSON: %v", err))
+		respondError(types.ErrorResponse{Error: fmt.Sprintf("invalid JSON: %v", err), Code: types.ErrCodeInvalidJSON})
 		return
 	}
 
-	// 验证请求
-	if req.Text == "" {
-		respondError("text field is required")
+	// 验证请求并设置默认值
+	if err := prepareRequest(&req); err != nil {
+		respondError(newErrorResponse(err, types.ErrCodeInvalidJSON))
 		return
 	}
 
-	// 设置默认值
-	if req.Mode == "" {
-		req.Mode = "sanitize"
-	}
-	if req.Strategy == "" {
-		req.Strategy = "redact"
-	}
-	if req.Level == "" {
-		req.Level = "standard"
-	}
-	if req.SemanticMode == "" {
-		req.SemanticMode = "off"
-	}
-
 	// 创建引擎并处理
-	eng := engine.NewEngine()
-	resp, err := eng.Process(&req)
+	eng, err := newEngine("")
 	if err != nil {
-		respondError(fmt.Sprintf("processing error: %v", err))
+		respondError(types.ErrorResponse{Error: err.Error(), Code: types.ErrCodeInternalError})
+		return
+	}
+	resp, err := eng.Process(context.Background(), &req)
+	if err != nil {
+		respondError(newErrorResponse(fmt.Errorf("processing error: %w", err), types.ErrCodeProcessingError))
 		return
 	}
 
 	// 输出响应
 	responseJSON, err := json.Marshal(resp)
 	if err != nil {
-		respondError(fmt.Sprintf("marshal error: %v", err))
+		respondError(types.ErrorResponse{Error: fmt.Sprintf("marshal error: %v", err), Code: types.ErrCodeInternalError})
 		return
 	}
 
 	fmt.Println(string(responseJSON))
 }
 
-func respondError(message string) {
-	errorResp := map[string]interface{}{
-		"error": message,
+// prepareRequest 验证请求的必需字段
+// 默认值、命名配置和枚举值的校验由引擎在处理时完成
+func prepareRequest(req *types.Request) error {
+	if req.Text == "" {
+		return &types.RequestError{Code: types.ErrCodeMissingText, Field: "text", Message: "text field is required"}
+	}
+	return nil
+}
+
+// newEngine 创建引擎并加载配置文件中的命名配置
+// configPath 为空时依次尝试环境变量和当前目录下的默认配置文件
+func newEngine(configPath string) (*engine.Engine, error) {
+	eng := engine.NewEngine()
+	path := config.Find(configPath)
+	if path == "" {
+		return eng, nil
 	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := eng.UseConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return eng, nil
+}
+
+// stringList 可重复指定的字符串参数
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// addRequestFlags 注册与 Request 字段对应的命令行参数，解析后调用返回的函数得到请求模板
+func addRequestFlags(fs *flag.FlagSet) func() types.Request {
+	profile := fs.String("profile", "", "使用的命名配置，其余参数覆盖配置中的值")
+	mode := fs.String("mode", "", "处理模式：sanitize | annotate（默认 sanitize）")
+	strategy := fs.String("strategy", "", "清洗策略：mask | redact | pseudonym（默认 redact）")
+	level := fs.String("level", "", "清洗强度：lenient | standard | strict（默认 standard）")
+	categories := fs.String("categories", "", "启用的类别，逗号分隔，默认全部")
+	var allowlist stringList
+	fs.Var(&allowlist, "allow", "白名单字符串（可重复）")
+	locale := fs.String("locale", "", "区域设置，如 en-US、en-GB，用于区分日期中的月和日")
+	contextualDates := fs.Bool("contextual-dates", false, "识别身份信息附近未带关键词的日期")
+
+	return func() types.Request {
+		req := types.Request{
+			Profile:   *profile,
+			Mode:      *mode,
+			Strategy:  *strategy,
+			Level:     *level,
+			Allowlist: allowlist,
+
+			Locale:          *locale,
+			ContextualDates: *contextualDates,
+		}
+		for _, cat := range strings.Split(*categories, ",") {
+			if cat = strings.TrimSpace(cat); cat != "" {
+				req.EnabledCategories = append(req.EnabledCategories, cat)
+			}
+		}
+		return req
+	}
+}
+
+// processRequest 调用引擎处理请求，将 panic 转为错误，避免单个请求导致常驻进程退出
+func processRequest(ctx context.Context, eng *engine.Engine, req *types.Request) (resp *types.Response, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return eng.Process(ctx, req)
+}
+
+// newErrorResponse 构造错误响应，请求校验错误使用其自带的错误码，其他错误使用 code
+func newErrorResponse(err error, code string) types.ErrorResponse {
+	var reqErr *types.RequestError
+	if errors.As(err, &reqErr) {
+		return types.ErrorResponse{Error: reqErr.Message, Code: reqErr.Code, Field: reqErr.Field}
+	}
+	return types.ErrorResponse{Error: err.Error(), Code: code}
+}
+
+func respondError(errorResp types.ErrorResponse) {
 	jsonBytes, _ := json.Marshal(errorResp)
 	fmt.Println(string(jsonBytes))
 	os.Exit(1)
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// binarySniffSize 判断二进制文件时读取的字节数
+const binarySniffSize = 8000
+
+// scanOptions 扫描命令的参数
+type scanOptions struct {
+	outDir     string
+	reportPath string
+	include    []string
+	exclude    []string
+	maxSize    int64
+	template   types.Request
+}
+
+// runScan 扫描文件、目录或 glob，并将清洗后的副本按原目录结构写入输出目录
+func runScan(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	outDir := fs.String("out", "", "输出目录（必需）")
+	reportPath := fs.String("report", "", "报告文件路径，默认为 <out>/scan-report.json")
+	maxSize := fs.Int64("max-size", 10*1024*1024, "单个文件的最大字节数，超过则跳过")
+	configPath := fs.String("config", "", "配置文件路径")
+	request := addRequestFlags(fs)
+	var include, exclude stringList
+	fs.Var(&include, "include", "只处理匹配的文件（glob，可重复）")
+	fs.Var(&exclude, "exclude", "跳过匹配的文件或目录（glob，可重复）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *outDir == "" {
+		return fmt.Errorf("-out is required")
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("at least one file, directory or glob is required")
+	}
+
+	opts := scanOptions{
+		outDir:     *outDir,
+		reportPath: *reportPath,
+		include:    include,
+		exclude:    exclude,
+		maxSize:    *maxSize,
+		template:   request(),
+	}
+	if opts.reportPath == "" {
+		opts.reportPath = filepath.Join(opts.outDir, "scan-report.json")
+	}
+
+	eng, err := newEngine(*configPath)
+	if err != nil {
+		return err
+	}
+	// 参数有误时每个文件都会失败，提前报错；合并配置后才能确定是否写出副本
+	resolved, _, err := eng.Resolve(&opts.template)
+	if err != nil {
+		return err
+	}
+	opts.template = *resolved
+
+	report, err := scanPaths(eng, fs.Args(), opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report error: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.reportPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.reportPath, data, 0o644); err != nil {
+		return fmt.Errorf("write report error: %w", err)
+	}
+
+	summary, _ := json.Marshal(map[string]interface{}{
+		"processed": report.Processed,
+		"skipped":   report.Skipped,
+		"failed":    report.Failed,
+		"report":    opts.reportPath,
+	})
+	fmt.Fprintln(stdout, string(summary))
+	return nil
+}
+
+// scanPaths 展开输入路径并逐个处理文件
+func scanPaths(eng *engine.Engine, paths []string, opts scanOptions) (*types.ScanReport, error) {
+	report := &types.ScanReport{
+		Version: engine.Version,
+		Stats:   types.Stats{ByCategory: make(map[string]int)},
+		Files:   make([]types.ScanFileResult, 0),
+	}
+
+	absOut, err := filepath.Abs(opts.outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := expandScanPaths(paths, absOut, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range files {
+		result := scanFile(eng, target, opts)
+		switch result.Status {
+		case "processed":
+			report.Processed++
+			mergeStats(&report.Stats, *result.Stats)
+		case "skipped":
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Files = append(report.Files, result)
+	}
+	return report, nil
+}
+
+// scanTarget 待处理的文件
+type scanTarget struct {
+	path string // 文件路径
+	rel  string // 输出目录中的相对路径：目录中的文件相对于该目录，单独指定的文件只保留文件名
+}
+
+// expandScanPaths 将文件、目录和 glob 展开为去重后的文件列表
+// 写出副本时不同文件的输出路径不能相同
+func expandScanPaths(paths []string, absOut string, opts scanOptions) ([]scanTarget, error) {
+	var files []scanTarget
+	seen := make(map[string]bool)
+	outputs := make(map[string]string)
+	add := func(path, rel string) error {
+		if seen[path] {
+			return nil
+		}
+		if other, ok := outputs[rel]; ok && opts.template.Mode != "annotate" {
+			return fmt.Errorf("%s and %s would both be written to %s", other, path, filepath.Join(opts.outDir, rel))
+		}
+		seen[path] = true
+		outputs[rel] = path
+		files = append(files, scanTarget{path: path, rel: rel})
+		return nil
+	}
+
+	for _, arg := range paths {
+		matches := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			globbed, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob %q: %w", arg, err)
+			}
+			if len(globbed) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+			matches = globbed
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				if shouldScan(match, match, opts) {
+					if err := add(match, filepath.Base(match)); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+
+			root := match
+			err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(root, path)
+				if d.IsDir() {
+					// 不扫描输出目录本身，避免重复处理上一次的结果
+					if abs, _ := filepath.Abs(path); abs == absOut {
+						return filepath.SkipDir
+					}
+					if path != root && matchesAny(opts.exclude, path, rel) {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if d.Type().IsRegular() && shouldScan(path, rel, opts) {
+					return add(path, rel)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// shouldScan 根据 include/exclude 规则判断文件是否需要处理
+func shouldScan(path, rel string, opts scanOptions) bool {
+	if matchesAny(opts.exclude, path, rel) {
+		return false
+	}
+	return len(opts.include) == 0 || matchesAny(opts.include, path, rel)
+}
+
+// matchesAny 检查文件名或相对路径是否匹配任一 glob
+func matchesAny(patterns []string, path, rel string) bool {
+	base := filepath.Base(path)
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(filepath.ToSlash(pattern), rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile 处理单个文件，失败或跳过时记录原因
+func scanFile(eng *engine.Engine, target scanTarget, opts scanOptions) types.ScanFileResult {
+	path := target.path
+	result := types.ScanFileResult{Path: path}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		result.Status, result.Reason = "error", err.Error()
+		return result
+	}
+	result.Size = info.Size()
+	if opts.maxSize > 0 && info.Size() > opts.maxSize {
+		result.Status = "skipped"
+		result.Reason = fmt.Sprintf("file too large (%d > %d bytes)", info.Size(), opts.maxSize)
+		return result
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		result.Status, result.Reason = "error", err.Error()
+		return result
+	}
+	if isBinary(data) {
+		result.Status, result.Reason = "skipped", "binary file"
+		return result
+	}
+	if len(data) == 0 {
+		result.Status, result.Reason = "skipped", "empty file"
+		return result
+	}
+
+	req := opts.template
+	req.Text = string(data)
+	if err := prepareRequest(&req); err != nil {
+		result.Status, result.Reason = "error", err.Error()
+		return result
+	}
+	resp, err := processRequest(context.Background(), eng, &req)
+	if err != nil {
+		result.Status, result.Reason = "error", fmt.Sprintf("processing error: %v", err)
+		return result
+	}
+
+	if req.Mode != "annotate" {
+		outPath := filepath.Join(opts.outDir, target.rel)
+		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+			result.Status, result.Reason = "error", err.Error()
+			return result
+		}
+		if err := os.WriteFile(outPath, []byte(resp.SanitizedText), info.Mode().Perm()); err != nil {
+			result.Status, result.Reason = "error", err.Error()
+			return result
+		}
+		result.OutputPath = outPath
+	}
+
+	result.Status = "processed"
+	result.Findings = resp.Findings
+	result.Stats = &resp.Stats
+	result.RiskScore = resp.RiskScore
+	return result
+}
+
+// isBinary 通过 NUL 字节和 UTF-8 合法性判断是否为二进制文件
+func isBinary(data []byte) bool {
+	sniff := data
+	if len(sniff) > binarySniffSize {
+		sniff = sniff[:binarySniffSize]
+		// 截断处可能切断多字节字符，回退到最后一个字符的起始位置
+		for i := len(sniff) - 1; i >= 0 && i >= len(sniff)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(sniff[i]) {
+				sniff = sniff[:i]
+				break
+			}
+		}
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return true
+	}
+	return !utf8.Valid(sniff)
+}
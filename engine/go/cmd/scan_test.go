@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+)
+
+func TestScanPaths(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	files := map[string]string{
+		"a.txt":       "我的手机号是13812345678",
+		"sub/b.md":    "邮箱是test@corp.io",
+		"sub/c.log":   "13900000000",
+		"image.bin":   "PNG\x00\x01\x02",
+		"big/big.txt": string(make([]byte, 2048)),
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := scanOptions{
+		outDir:  filepath.Join(root, "out"),
+		exclude: []string{"*.log"},
+		maxSize: 1024,
+	}
+	opts.template.Mode = "sanitize"
+	report, err := scanPaths(engine.NewEngine(), []string{src}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Processed != 2 || report.Skipped != 2 || report.Failed != 0 {
+		t.Fatalf("unexpected report: processed=%d skipped=%d failed=%d", report.Processed, report.Skipped, report.Failed)
+	}
+
+	for _, f := range report.Files {
+		if f.Status != "processed" {
+			continue
+		}
+		// 输出路径相对于输入目录，不包含 src 之前的绝对路径
+		rel, err := filepath.Rel(src, f.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.OutputPath != filepath.Join(opts.outDir, rel) {
+			t.Errorf("expected output %s for %s, got %s", filepath.Join(opts.outDir, rel), f.Path, f.OutputPath)
+		}
+		data, err := os.ReadFile(f.OutputPath)
+		if err != nil {
+			t.Fatalf("missing output for %s: %v", f.Path, err)
+		}
+		original, ok := files[filepath.ToSlash(rel)]
+		if !ok || string(data) == original {
+			t.Errorf("output for %s was not sanitized", f.Path)
+		}
+	}
+}
+
+func TestScanOutputConflict(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"a", "b"} {
+		path := filepath.Join(root, dir, "notes.txt")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("13812345678"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{filepath.Join(root, "a"), filepath.Join(root, "b")}
+
+	// 两个目录中的同名文件会写到同一个输出路径
+	opts := scanOptions{outDir: filepath.Join(root, "out")}
+	opts.template.Mode = "sanitize"
+	if _, err := scanPaths(engine.NewEngine(), paths, opts); err == nil {
+		t.Error("expected output conflict error")
+	}
+
+	// annotate 模式不写出副本，不会冲突
+	opts.template.Mode = "annotate"
+	report, err := scanPaths(engine.NewEngine(), paths, opts)
+	if err != nil || report.Processed != 2 {
+		t.Errorf("unexpected result: %+v %v", report, err)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected bool
+	}{
+		{"纯文本", []byte("hello 世界"), false},
+		{"NUL字节", []byte("abc\x00def"), true},
+		{"非法UTF-8", []byte{0xff, 0xfe, 0x41}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBinary(tt.data); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
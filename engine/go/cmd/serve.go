@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// maxServeLineSize 单行消息的最大长度（64MB）
+const maxServeLineSize = 64 * 1024 * 1024
+
+// server 常驻模式：复用同一个引擎实例，按行读写 JSON 消息
+type server struct {
+	eng *engine.Engine
+
+	outMu sync.Mutex
+	out   *json.Encoder
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// runServe 运行常驻模式，直到收到 shutdown 消息或 stdin 关闭
+func runServe(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	configPath := fs.String("config", "", "配置文件路径")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	eng, err := newEngine(*configPath)
+	if err != nil {
+		return err
+	}
+	return serve(eng, in, out)
+}
+
+// serve 使用给定的引擎处理 in 中的消息，响应写入 out
+func serve(eng *engine.Engine, in io.Reader, out io.Writer) error {
+	s := &server{
+		eng:      eng,
+		out:      json.NewEncoder(out),
+		inflight: make(map[string]context.CancelFunc),
+	}
+	s.reply(types.ServeReply{Type: "ready"})
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxServeLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var msg types.ServeMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			s.reply(types.ServeReply{Type: "error", Error: fmt.Sprintf("invalid JSON: %v", err), Code: types.ErrCodeInvalidJSON})
+			continue
+		}
+
+		switch msg.Type {
+		case "", "process":
+			s.start(msg)
+		case "cancel":
+			s.cancel(msg.ID)
+		case "shutdown":
+			s.shutdown()
+			return nil
+		default:
+			s.reply(types.ServeReply{ID: msg.ID, Type: "error", Error: fmt.Sprintf("unknown message type: %s", msg.Type), Code: types.ErrCodeInvalidMessage})
+		}
+	}
+
+	// stdin 关闭时同样等待进行中的请求完成
+	s.shutdown()
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read stdin error: %w", err)
+	}
+	return nil
+}
+
+// start 在独立 goroutine 中处理一个请求
+func (s *server) start(msg types.ServeMessage) {
+	if msg.ID == "" {
+		s.reply(types.ServeReply{Type: "error", Error: "id field is required", Code: types.ErrCodeInvalidMessage})
+		return
+	}
+	if msg.Request == nil {
+		s.reply(types.ServeReply{ID: msg.ID, Type: "error", Error: "request field is required", Code: types.ErrCodeInvalidMessage})
+		return
+	}
+	req := msg.Request
+	if err := prepareRequest(req); err != nil {
+		s.replyError(msg.ID, err, types.ErrCodeInvalidJSON)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	if _, exists := s.inflight[msg.ID]; exists {
+		s.mu.Unlock()
+		cancel()
+		s.reply(types.ServeReply{ID: msg.ID, Type: "error", Error: fmt.Sprintf("duplicate request id: %s", msg.ID), Code: types.ErrCodeInvalidMessage})
+		return
+	}
+	s.inflight[msg.ID] = cancel
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		// cancel 消息会取消 ctx，引擎随即停止检测
+		resp, err := processRequest(ctx, s.eng, req)
+
+		// 已被取消的请求不再输出结果
+		if !s.finish(msg.ID, ctx) {
+			return
+		}
+		if err != nil {
+			s.replyError(msg.ID, fmt.Errorf("processing error: %w", err), types.ErrCodeProcessingError)
+			return
+		}
+		s.reply(types.ServeReply{ID: msg.ID, Type: "result", Response: resp})
+	}()
+}
+
+// finish 将请求移出进行中列表，返回是否仍需输出结果
+func (s *server) finish(id string, ctx context.Context) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	delete(s.inflight, id)
+	return true
+}
+
+// cancel 取消一个进行中的请求
+func (s *server) cancel(id string) {
+	s.mu.Lock()
+	cancel, ok := s.inflight[id]
+	if ok {
+		delete(s.inflight, id)
+		cancel()
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.reply(types.ServeReply{ID: id, Type: "error", Error: fmt.Sprintf("unknown request id: %s", id), Code: types.ErrCodeInvalidMessage})
+		return
+	}
+	s.reply(types.ServeReply{ID: id, Type: "cancelled"})
+}
+
+// shutdown 等待所有进行中的请求完成后输出 shutdown 消息
+func (s *server) shutdown() {
+	s.wg.Wait()
+	s.reply(types.ServeReply{Type: "shutdown"})
+}
+
+// replyError 输出错误响应，请求校验错误使用其自带的错误码
+func (s *server) replyError(id string, err error, code string) {
+	errResp := newErrorResponse(err, code)
+	s.reply(types.ServeReply{ID: id, Type: "error", Error: errResp.Error, Code: errResp.Code})
+}
+
+// reply 输出一行响应，多个 goroutine 共享 stdout 需要加锁
+func (s *server) reply(r types.ServeReply) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	_ = s.out.Encode(r)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prompt-sanitizer/engine/internal/detector"
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// gateDetector 通知测试已开始检测，然后一直等到 release 关闭或 ctx 结束，用来让请求停留在进行中
+type gateDetector struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGateDetector() *gateDetector {
+	return &gateDetector{started: make(chan struct{}, 16), release: make(chan struct{})}
+}
+
+func (d *gateDetector) Category() detector.Category { return "gate" }
+
+func (d *gateDetector) Detect(ctx context.Context, text string, level string) []detector.Finding {
+	d.started <- struct{}{}
+	select {
+	case <-d.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// wait 等待 n 个请求开始检测
+func (d *gateDetector) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-d.started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d of %d requests started", i, n)
+		}
+	}
+}
+
+// serveSession 通过内存管道驱动常驻模式
+type serveSession struct {
+	in      *io.PipeWriter
+	replies chan types.ServeReply
+	done    chan error
+}
+
+func startServe(t *testing.T, eng *engine.Engine) *serveSession {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	s := &serveSession{in: inW, replies: make(chan types.ServeReply, 16), done: make(chan error, 1)}
+	go func() {
+		err := serve(eng, inR, outW)
+		outW.Close()
+		s.done <- err
+	}()
+	go func() {
+		defer close(s.replies)
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			var r types.ServeReply
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				t.Errorf("invalid reply %q: %v", scanner.Text(), err)
+				continue
+			}
+			s.replies <- r
+		}
+	}()
+	t.Cleanup(func() { inW.Close() })
+	if r := s.next(t); r.Type != "ready" {
+		t.Fatalf("expected ready, got %+v", r)
+	}
+	return s
+}
+
+func (s *serveSession) send(t *testing.T, line string) {
+	t.Helper()
+	if _, err := io.WriteString(s.in, line+"\n"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (s *serveSession) next(t *testing.T) types.ServeReply {
+	t.Helper()
+	select {
+	case r, ok := <-s.replies:
+		if !ok {
+			t.Fatal("output closed")
+		}
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reply")
+	}
+	return types.ServeReply{}
+}
+
+func TestServeMessages(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"1","type":"process","request":{"text":"我的手机号是13812345678"}}`,
+		`{"id":"2","type":"cancel"}`,
+		`{"id":"3","type":"process"}`,
+		`{"type":"process","request":{"text":"a"}}`,
+		`{"id":"4","type":"ping"}`,
+		`{"id":"5","type":`,
+		``,
+		`{"type":"shutdown"}`,
+		`{"id":"6","type":"process","request":{"text":"shutdown 之后的消息不再处理"}}`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := runServe(nil, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var replies []types.ServeReply
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var r types.ServeReply
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid reply %q: %v", line, err)
+		}
+		replies = append(replies, r)
+	}
+	if len(replies) != 8 || replies[0].Type != "ready" || replies[len(replies)-1].Type != "shutdown" {
+		t.Fatalf("unexpected replies: %+v", replies)
+	}
+
+	// 处理结果与错误消息的先后取决于 goroutine 调度，错误消息按 ID 和错误码比较
+	var result *types.Response
+	var errs []string
+	for _, r := range replies[1 : len(replies)-1] {
+		switch r.Type {
+		case "result":
+			result = r.Response
+		case "error":
+			errs = append(errs, r.ID+":"+r.Code)
+		default:
+			t.Errorf("unexpected reply: %+v", r)
+		}
+	}
+	if result == nil || result.SanitizedText != "我的手机号是[REDACTED:PHONE]" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	expected := []string{
+		"2:" + types.ErrCodeInvalidMessage, // 取消未知的请求
+		"3:" + types.ErrCodeInvalidMessage, // 缺少 request
+		":" + types.ErrCodeInvalidMessage,  // 缺少 id
+		"4:" + types.ErrCodeInvalidMessage, // 未知消息类型
+		":" + types.ErrCodeInvalidJSON,     // 无效 JSON
+	}
+	if strings.Join(errs, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected errors %v, got %v", expected, errs)
+	}
+}
+
+func TestServeConcurrentAndCancel(t *testing.T) {
+	eng := engine.NewEngine()
+	gate := newGateDetector()
+	eng.AddDetector(gate)
+	s := startServe(t, eng)
+
+	// 两个请求同时进行，第二个不需要等第一个完成
+	s.send(t, `{"id":"a","request":{"text":"13812345678"}}`)
+	s.send(t, `{"id":"b","request":{"text":"13812345678"}}`)
+	gate.wait(t, 2)
+
+	// 进行中的 ID 不能重复使用
+	s.send(t, `{"id":"a","request":{"text":"13812345678"}}`)
+	if r := s.next(t); r.ID != "a" || r.Type != "error" || r.Code != types.ErrCodeInvalidMessage {
+		t.Errorf("expected duplicate id error, got %+v", r)
+	}
+
+	// 取消后不再输出 a 的结果，再次取消时 a 已是未知 ID
+	s.send(t, `{"id":"a","type":"cancel"}`)
+	if r := s.next(t); r.ID != "a" || r.Type != "cancelled" {
+		t.Errorf("expected cancelled, got %+v", r)
+	}
+	s.send(t, `{"id":"a","type":"cancel"}`)
+	if r := s.next(t); r.ID != "a" || r.Type != "error" {
+		t.Errorf("expected unknown id error, got %+v", r)
+	}
+
+	close(gate.release)
+	if r := s.next(t); r.ID != "b" || r.Type != "result" || r.Response.SanitizedText != "[REDACTED:PHONE]" {
+		t.Errorf("expected result for b, got %+v", r)
+	}
+
+	// 完成后 ID 可以再次使用
+	s.send(t, `{"id":"b","request":{"text":"13812345678"}}`)
+	gate.wait(t, 1)
+	if r := s.next(t); r.ID != "b" || r.Type != "result" {
+		t.Errorf("expected result for reused id, got %+v", r)
+	}
+
+	s.send(t, `{"type":"shutdown"}`)
+	if r := s.next(t); r.Type != "shutdown" {
+		t.Errorf("expected shutdown, got %+v", r)
+	}
+	if err := <-s.done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestServeShutdownWaitsForInflight(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		close func(s *serveSession, t *testing.T)
+	}{
+		{"shutdown消息", func(s *serveSession, t *testing.T) { s.send(t, `{"type":"shutdown"}`) }},
+		{"输入关闭", func(s *serveSession, t *testing.T) { s.in.Close() }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			eng := engine.NewEngine()
+			gate := newGateDetector()
+			eng.AddDetector(gate)
+			s := startServe(t, eng)
+
+			s.send(t, `{"id":"a","request":{"text":"13812345678"}}`)
+			gate.wait(t, 1)
+			tt.close(s, t)
+
+			// 进行中的请求完成之前不能退出
+			select {
+			case err := <-s.done:
+				t.Fatalf("serve returned before in-flight request finished: %v", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(gate.release)
+			if r := s.next(t); r.ID != "a" || r.Type != "result" {
+				t.Errorf("expected result before shutdown, got %+v", r)
+			}
+			if r := s.next(t); r.Type != "shutdown" {
+				t.Errorf("expected shutdown, got %+v", r)
+			}
+			if err := <-s.done; err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
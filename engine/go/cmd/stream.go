@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// runStream 流式清洗超大输入，文本写入输出，findings 写入 JSONL 报告
+func runStream(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("stream", flag.ContinueOnError)
+	inputPath := fs.String("input", "-", "输入文件路径，- 表示 stdin")
+	outputPath := fs.String("output", "-", "输出文件路径，- 表示 stdout")
+	reportPath := fs.String("report", "", "findings 报告路径（JSONL，每行一个 finding，最后一行为汇总）")
+	chunkSize := fs.Int("chunk-size", engine.DefaultChunkSize, "每个窗口新读入的字节数")
+	overlap := fs.Int("overlap", engine.DefaultOverlap, "相邻窗口的重叠字节数，也是跨边界匹配的最大长度")
+	configPath := fs.String("config", "", "配置文件路径")
+	request := addRequestFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// 流式模式下文本来自输入流，请求中只有处理参数
+	req := request()
+	eng, err := newEngine(*configPath)
+	if err != nil {
+		return err
+	}
+	if _, _, err := eng.Resolve(&req); err != nil {
+		return err
+	}
+
+	in := stdin
+	if *inputPath != "-" {
+		f, err := os.Open(*inputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	out := stdout
+	if *outputPath != "-" {
+		f, err := os.Create(*outputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	writer := bufio.NewWriter(out)
+
+	opts := engine.StreamOptions{ChunkSize: *chunkSize, Overlap: *overlap}
+	var report *json.Encoder
+	var reportWriter *bufio.Writer
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		reportWriter = bufio.NewWriter(f)
+		report = json.NewEncoder(reportWriter)
+	}
+	// 不需要报告时同样通过回调丢弃 findings，避免在内存中累积
+	var reportErr error
+	opts.OnFinding = func(f types.Finding) {
+		if report != nil && reportErr == nil {
+			reportErr = report.Encode(f)
+		}
+	}
+
+	resp, err := eng.ProcessStream(context.Background(), &req, in, writer, opts)
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if report != nil {
+		if reportErr != nil {
+			return fmt.Errorf("write report error: %w", reportErr)
+		}
+		if err := report.Encode(map[string]interface{}{
+			"summary": map[string]interface{}{
+				"stats":      resp.Stats,
+				"risk_score": resp.RiskScore,
+				"version":    resp.Version,
+			},
+		}); err != nil {
+			return fmt.Errorf("write report error: %w", err)
+		}
+		return reportWriter.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+const (
+	// DefaultFileName 未指定配置文件时在当前目录查找的文件名
+	DefaultFileName = ".prompt-sanitizer.json"
+	// EnvVar 指定配置文件路径的环境变量
+	EnvVar = "PROMPT_SANITIZER_CONFIG"
+)
+
+// Config 表示配置文件
+type Config struct {
+	DefaultProfile string                   `json:"default_profile"` // 请求未指定 profile 时使用的配置
+	Profiles       map[string]types.Profile `json:"profiles"`
+	Limits         Limits                   `json:"limits"`
+
+	// CorporateRanges 企业内网网段（CIDR 或单个地址），命中的 IP 地址风险高于普通私有地址
+	CorporateRanges []string `json:"corporate_ranges"`
+	// PhoneRegions 识别国内格式电话号码的地区（ISO 3166-1，如 CN、HK），省略时只识别中国大陆号码
+	PhoneRegions []string `json:"phone_regions"`
+}
+
+// Limits 单次请求的资源预算，省略或为 0 时使用引擎默认值
+type Limits struct {
+	MaxInputSize int `json:"max_input_size"` // 文本最大字节数
+	TimeoutMs    int `json:"timeout_ms"`     // 最长处理时间（毫秒）
+}
+
+// Load 读取配置文件，未知字段视为错误，避免拼写错误被静默忽略
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config error: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var cfg Config
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	if cfg.Limits.MaxInputSize < 0 || cfg.Limits.TimeoutMs < 0 {
+		return nil, fmt.Errorf("invalid config %s: limits must not be negative", path)
+	}
+	if cfg.DefaultProfile != "" {
+		if _, ok := cfg.Profiles[cfg.DefaultProfile]; !ok {
+			return nil, fmt.Errorf("invalid config %s: default_profile %q is not defined", path, cfg.DefaultProfile)
+		}
+	}
+	return &cfg, nil
+}
+
+// Find 按优先级确定配置文件路径：显式指定 > 环境变量 > 当前目录下的默认文件
+// 都不存在时返回空字符串
+func Find(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if path := os.Getenv(EnvVar); path != "" {
+		return path
+	}
+	if _, err := os.Stat(DefaultFileName); err == nil {
+		return DefaultFileName
+	}
+	return ""
+}
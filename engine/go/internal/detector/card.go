@@ -0,0 +1,198 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// 卡组织
+const (
+	NetworkUnionPay   = "unionpay"
+	NetworkVisa       = "visa"
+	NetworkMastercard = "mastercard"
+	NetworkAmex       = "amex"
+	NetworkJCB        = "jcb"
+	NetworkDiscover   = "discover"
+)
+
+// cardPattern 13-19位卡号，可以按 3-6 位一组用空格或横线分隔
+var cardPattern = regexp.MustCompile(`\b\d{3,6}(?:[- ]\d{3,6}){2,5}\b|\b\d{13,19}\b`)
+
+// cardIIN 发卡行识别号（IIN/BIN）范围，按前缀长度比较
+type cardIIN struct {
+	network string
+	low     int // 前缀下界（含）
+	high    int // 前缀上界（含）
+	digits  int // 前缀位数
+	lengths []int
+}
+
+// cardIINs 按顺序匹配，UnionPay 与 Discover 联名的 622126-622925 归为 UnionPay
+var cardIINs = []cardIIN{
+	{NetworkUnionPay, 62, 62, 2, []int{16, 17, 18, 19}},
+	{NetworkVisa, 4, 4, 1, []int{13, 16, 19}},
+	{NetworkMastercard, 51, 55, 2, []int{16}},
+	{NetworkMastercard, 2221, 2720, 4, []int{16}},
+	{NetworkAmex, 34, 34, 2, []int{15}},
+	{NetworkAmex, 37, 37, 2, []int{15}},
+	{NetworkJCB, 3528, 3589, 4, []int{16, 17, 18, 19}},
+	{NetworkDiscover, 6011, 6011, 4, []int{16, 17, 18, 19}},
+	{NetworkDiscover, 644, 649, 3, []int{16, 17, 18, 19}},
+	{NetworkDiscover, 65, 65, 2, []int{16, 17, 18, 19}},
+}
+
+// cardInfo 卡号的识别结果，银行卡和信用卡检测器共用，保证同一串数字只归入一个类别
+type cardInfo struct {
+	network  string   // 卡组织，未识别时为空
+	luhn     bool     // 是否通过 Luhn 校验
+	category Category // UnionPay 和未识别的卡号归为银行卡，其他卡组织归为信用卡
+}
+
+// classifyCard 识别卡组织并做 Luhn 校验，digits 只包含数字
+func classifyCard(digits string) cardInfo {
+	info := cardInfo{luhn: luhnValid(digits), category: CategoryBankCard}
+	for _, iin := range cardIINs {
+		prefix := 0
+		for _, c := range digits[:iin.digits] {
+			prefix = prefix*10 + int(c-'0')
+		}
+		if prefix < iin.low || prefix > iin.high || !containsInt(iin.lengths, len(digits)) {
+			continue
+		}
+		info.network = iin.network
+		if iin.network != NetworkUnionPay {
+			info.category = CategoryCreditCard
+		}
+		break
+	}
+	return info
+}
+
+// luhnValid Luhn（模 10）校验
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var cardNetworkNames = map[string]string{
+	NetworkUnionPay:   "银联",
+	NetworkVisa:       "Visa",
+	NetworkMastercard: "Mastercard",
+	NetworkAmex:       "American Express",
+	NetworkJCB:        "JCB",
+	NetworkDiscover:   "Discover",
+}
+
+// detectCards 识别属于 category 的卡号
+//
+// Luhn 校验通过且卡组织已知：高置信度
+// Luhn 校验通过但卡组织未知：标准和严格模式下以中等置信度报告为银行卡
+// Luhn 校验不通过：只在严格模式下报告卡组织已知的号码（可能是输错的卡号）
+func detectCards(ctx context.Context, text, level string, category Category, risk int) []Finding {
+	var findings []Finding
+	matches := cardPattern.FindAllStringIndex(text, -1)
+	for _, match := range matches {
+		if ctx.Err() != nil {
+			return findings
+		}
+		matchedText := text[match[0]:match[1]]
+		digits := strings.NewReplacer("-", "", " ", "").Replace(matchedText)
+		if len(digits) < 13 || len(digits) > 19 || isRepeatingDigits(digits) {
+			continue
+		}
+
+		// 未分隔的18位数字若地区码和出生日期有效，按身份证处理
+		if len(digits) == 18 && digits == matchedText && idCardRegionValid(digits) && idCardBirthValid(digits) {
+			continue
+		}
+		// 税号、注册号等关键词后通过企业证照校验的号码和通过校验的统一社会信用代码由 CompanyIDDetector 处理
+		if digits == matchedText && (companyIDClaimed(text, match[0], digits) || unlabeledCreditCode(digits)) {
+			continue
+		}
+
+		info := classifyCard(digits)
+		if info.category != category {
+			continue
+		}
+
+		finding := Finding{
+			Type:    category,
+			Start:   match[0],
+			End:     match[1],
+			Text:    matchedText,
+			Network: info.network,
+		}
+		switch {
+		case info.luhn && info.network != "":
+			finding.Confidence = 0.95
+			finding.Risk = risk
+			finding.Reason = "检测到" + cardNetworkNames[info.network] + "卡号（Luhn 校验通过）"
+		case info.luhn:
+			if level == "lenient" || len(digits) < 16 {
+				continue
+			}
+			finding.Confidence = 0.6
+			finding.Risk = 70
+			finding.Reason = "检测到疑似银行卡号（Luhn 校验通过，发卡组织未知）"
+		default:
+			if level != "strict" || info.network == "" {
+				continue
+			}
+			finding.Confidence = 0.4
+			finding.Risk = 50
+			finding.Reason = "检测到疑似" + cardNetworkNames[info.network] + "卡号（Luhn 校验不通过）"
+		}
+		valid := info.luhn
+		finding.Valid = &valid
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// BankCardDetector 银行卡号检测器（银联及未识别卡组织的卡号）
+type BankCardDetector struct {
+	BaseDetector
+}
+
+func NewBankCardDetector() *BankCardDetector {
+	return &BankCardDetector{BaseDetector{category: CategoryBankCard}}
+}
+
+func (d *BankCardDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	return detectCards(ctx, text, level, CategoryBankCard, 90)
+}
+
+// CreditCardDetector 信用卡号检测器（Visa、Mastercard、American Express、JCB、Discover）
+type CreditCardDetector struct {
+	BaseDetector
+}
+
+func NewCreditCardDetector() *CreditCardDetector {
+	return &CreditCardDetector{BaseDetector{category: CategoryCreditCard}}
+}
+
+func (d *CreditCardDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	return detectCards(ctx, text, level, CategoryCreditCard, 95)
+}
@@ -0,0 +1,227 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// CompanyIDDetector 企业证照号码检测器：统一社会信用代码（GB 32100）、15 位工商注册号（GS 15）和组织机构代码（GB 11714）
+type CompanyIDDetector struct {
+	BaseDetector
+}
+
+func NewCompanyIDDetector() *CompanyIDDetector {
+	return &CompanyIDDetector{BaseDetector{category: CategoryCompanyID}}
+}
+
+var (
+	// 统一社会信用代码：登记管理部门、机构类别、6 位行政区划码、9 位组织机构代码、校验码，不使用 I、O、S、V、Z
+	creditCodePattern = regexp.MustCompile(`\b[0-9A-HJ-NPQRTUWXY]{2}\d{6}[0-9A-HJ-NPQRTUWXY]{10}\b`)
+	// 15 位工商注册号或旧版税务登记号（6 位行政区划码 + 9 位组织机构代码），只在关键词后识别
+	companyRegNoPattern = regexp.MustCompile(`\b\d{6}[0-9A-Z]{8}[0-9X]\b`)
+	// 组织机构代码，带连字符的格式在没有关键词时也识别
+	orgCodePattern = regexp.MustCompile(`\b[0-9A-Z]{8}-?[0-9X]\b`)
+	// companyLabelPattern 企业证照关键词，匹配到的号码紧跟其后
+	companyLabelPattern = regexp.MustCompile(`(?i)(?:统一社会信用代码|社会信用代码|信用代码|纳税人识别号|税号|税务登记号|工商注册号|注册号|营业执照号码?|组织机构代码|机构代码|\bUSCC|\btax\s*id)[\s:：=是为]*$`)
+)
+
+// companyLabelWindow 关键词与号码之间允许的最大距离（字节）
+const companyLabelWindow = 40
+
+const creditCodeChars = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+// creditCodeWeights 统一社会信用代码前 17 位的加权因子（3^i mod 31）
+var creditCodeWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// orgCodeWeights 组织机构代码前 8 位的加权因子
+var orgCodeWeights = [8]int{3, 7, 9, 10, 5, 8, 4, 2}
+
+func (d *CompanyIDDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	add := func(start, end int, valid bool, confidence float64, risk int, reason string) {
+		for _, f := range findings {
+			if start < f.End && f.Start < end {
+				return
+			}
+		}
+		v := valid
+		findings = append(findings, Finding{
+			Type:       CategoryCompanyID,
+			Start:      start,
+			End:        end,
+			Text:       text[start:end],
+			Confidence: confidence,
+			Risk:       risk,
+			Reason:     reason,
+			Valid:      &v,
+		})
+	}
+	for _, match := range creditCodePattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		code := text[start:end]
+		valid, labeled := creditCodeValid(code), companyIDLabeled(text, start)
+		switch {
+		case labeled && valid:
+			add(start, end, true, 0.95, 60, "检测到统一社会信用代码（校验码有效）")
+		case labeled:
+			if level != "lenient" {
+				add(start, end, false, 0.5, 50, "检测到疑似统一社会信用代码（校验码无效）")
+			}
+		case unlabeledCreditCode(code):
+			add(start, end, true, 0.85, 60, "检测到统一社会信用代码（校验码有效）")
+		}
+	}
+
+	for _, match := range companyRegNoPattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		if !companyIDLabeled(text, start) {
+			continue
+		}
+		code := text[start:end]
+		switch {
+		case isDigits(code) && companyRegNoValid(code):
+			add(start, end, true, 0.9, 60, "检测到工商注册号（校验码有效）")
+		case orgCodeValid(code[6:]):
+			add(start, end, true, 0.85, 60, "检测到税务登记号（组织机构代码校验有效）")
+		case level != "lenient":
+			add(start, end, false, 0.5, 50, "检测到疑似工商注册号（校验码无效）")
+		}
+	}
+
+	for _, match := range orgCodePattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		code := strings.Replace(text[start:end], "-", "", 1)
+		valid, labeled := orgCodeValid(code), companyIDLabeled(text, start)
+		switch {
+		case labeled && valid:
+			add(start, end, true, 0.9, 50, "检测到组织机构代码（校验码有效）")
+		case labeled:
+			if level != "lenient" {
+				add(start, end, false, 0.5, 40, "检测到疑似组织机构代码（校验码无效）")
+			}
+		case valid && level != "lenient" && len(code) < end-start && strings.ContainsAny(code[:8], "0123456789"):
+			add(start, end, true, 0.7, 50, "检测到组织机构代码格式（校验码有效）")
+		}
+	}
+	return findings
+}
+
+// creditCodeValid 统一社会信用代码校验码：31 - 加权和 mod 31，结果为 31 时取 0
+// 第 3-8 位的行政区划码首位不能为 0
+func creditCodeValid(code string) bool {
+	if len(code) != 18 || code[2] == '0' {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		v := strings.IndexByte(creditCodeChars, code[i])
+		if v < 0 {
+			return false
+		}
+		sum += v * creditCodeWeights[i]
+	}
+	check := (31 - sum%31) % 31
+	return code[17] == creditCodeChars[check]
+}
+
+// companyRegNoValid 15 位工商注册号校验码（ISO 7064 MOD 11,10）
+func companyRegNoValid(code string) bool {
+	return len(code) == 15 && code[0] != '0' && mod1110Valid(code)
+}
+
+// mod1110Valid ISO 7064 MOD 11,10 校验，最后一位为校验码，code 必须全是数字
+func mod1110Valid(code string) bool {
+	p := 10
+	for i := 0; i < len(code)-1; i++ {
+		s := (p + int(code[i]-'0')) % 10
+		if s == 0 {
+			s = 10
+		}
+		p = s * 2 % 11
+	}
+	return int(code[len(code)-1]-'0') == (11-p)%10
+}
+
+// orgCodeValid 组织机构代码校验码：11 - 加权和 mod 11，10 为 X，11 为 0
+func orgCodeValid(code string) bool {
+	if len(code) != 9 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		c := code[i]
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		default:
+			return false
+		}
+		sum += v * orgCodeWeights[i]
+	}
+	var check byte
+	switch c := 11 - sum%11; c {
+	case 10:
+		check = 'X'
+	case 11:
+		check = '0'
+	default:
+		check = byte('0' + c)
+	}
+	return code[8] == check
+}
+
+// unlabeledCreditCode 没有关键词时按统一社会信用代码处理的号码
+// 纯数字的代码只接受常见的登记管理部门（1 机构编制、5 民政、9 工商），且地区码和出生日期不能同时有效，避免与身份证号、卡号混淆
+func unlabeledCreditCode(code string) bool {
+	if !creditCodeValid(code) {
+		return false
+	}
+	if !isDigits(code) {
+		return true
+	}
+	return strings.IndexByte("159", code[0]) >= 0 && !(idCardRegionValid(code) && idCardBirthValid(code))
+}
+
+// companyIDLabeled 号码紧跟在企业证照关键词之后
+func companyIDLabeled(text string, start int) bool {
+	return companyLabelPattern.MatchString(text[max(start-companyLabelWindow, 0):start])
+}
+
+// companyIDClaimed 关键词后的号码通过了对应企业证照的校验，由 CompanyIDDetector 识别，
+// 身份证、银行卡等检测器据此跳过；校验不通过的号码（如写在税号后的个人身份证号）仍由各检测器识别
+func companyIDClaimed(text string, start int, code string) bool {
+	if !companyIDLabeled(text, start) {
+		return false
+	}
+	switch len(code) {
+	case 18:
+		return creditCodeValid(code)
+	case 15:
+		return isDigits(code) && companyRegNoValid(code) || orgCodeValid(code[6:])
+	case 9:
+		return orgCodeValid(code)
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,180 @@
+package detector
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// contextRule 类别的上下文关键词规则
+// 匹配前后 window 字节内（不跨行）出现的关键词按权重调整置信度，风险按相同比例调整
+type contextRule struct {
+	window   int
+	keywords map[string]float64 // 关键词（小写）及置信度调整量，正数为正向、负数为负向
+	pattern  *regexp.Regexp
+}
+
+// newContextRule 合并关键词表并编译匹配模式，同一关键词以后出现的权重为准
+func newContextRule(window int, tables ...map[string]float64) *contextRule {
+	keywords := make(map[string]float64)
+	for _, table := range tables {
+		for word, weight := range table {
+			keywords[strings.ToLower(word)] = weight
+		}
+	}
+	words := make([]string, 0, len(keywords))
+	for word := range keywords {
+		words = append(words, word)
+	}
+	// 长关键词优先，如 "联系电话" 先于 "电话"
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	alternatives := make([]string, len(words))
+	for i, word := range words {
+		alternatives[i] = regexp.QuoteMeta(word)
+	}
+	pattern := regexp.MustCompile(`(?i)` + strings.Join(alternatives, "|"))
+	return &contextRule{window: window, keywords: keywords, pattern: pattern}
+}
+
+// 各类别共用的关键词表
+var (
+	// 测试和示例数据
+	contextSampleWords = map[string]float64{
+		"测试": -0.2, "示例": -0.3, "样例": -0.3, "模拟": -0.2, "虚构": -0.3,
+		"test": -0.2, "example": -0.3, "sample": -0.3, "dummy": -0.3, "fake": -0.3, "mock": -0.2,
+	}
+	// 与个人信息无关的编号，常见于长数字串附近
+	contextSerialWords = map[string]float64{
+		"订单": -0.4, "单号": -0.4, "流水号": -0.4, "交易号": -0.4, "运单": -0.4, "快递": -0.3, "发票": -0.3, "编号": -0.2, "版本": -0.4,
+		"order": -0.4, "invoice": -0.4, "tracking": -0.4, "serial": -0.3, "version": -0.4,
+	}
+)
+
+// contextRules 各类别的上下文规则，未声明的类别（包括自定义检测器）不调整
+var contextRules = map[Category]*contextRule{
+	CategoryIDCard: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"身份证": 0.15, "身分證": 0.15, "证件号": 0.1, "公民身份": 0.15, "id card": 0.1, "id_card": 0.1, "id number": 0.1,
+	}),
+	CategoryPhone: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"电话": 0.1, "手机": 0.1, "联系方式": 0.1, "致电": 0.1, "拨打": 0.1, "座机": 0.1,
+		"tel": 0.1, "phone": 0.1, "mobile": 0.1, "cell": 0.05, "call": 0.05, "whatsapp": 0.1,
+	}),
+	CategoryBankCard: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"银行卡": 0.15, "卡号": 0.1, "账号": 0.1, "账户": 0.1, "开户": 0.1, "收款": 0.1, "转账": 0.1, "储蓄卡": 0.15, "借记卡": 0.15,
+		"account": 0.1, "bank": 0.1, "debit": 0.1,
+	}),
+	CategoryCreditCard: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"信用卡": 0.15, "卡号": 0.1, "有效期": 0.1, "安全码": 0.1,
+		"credit card": 0.15, "card number": 0.1, "expiry": 0.1, "cvv": 0.1,
+	}),
+	CategoryPassport: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"护照": 0.15, "通行证": 0.15, "回乡证": 0.15, "台胞证": 0.15, "证件": 0.05, "出入境": 0.1, "passport": 0.15,
+	}),
+	CategoryNationalID: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"证件": 0.05, "身份": 0.1, "identity": 0.1, "national id": 0.1, "tax id": 0.1, "social security": 0.15,
+	}),
+	CategoryDriverLicense: newContextRule(48, contextSampleWords, contextSerialWords, map[string]float64{
+		"驾驶证": 0.15, "驾照": 0.15, "driver": 0.1, "driving": 0.1,
+	}),
+	CategoryEmail: newContextRule(32, contextSampleWords, map[string]float64{
+		"邮箱": 0.05, "邮件": 0.05, "联系": 0.05, "email": 0.05, "e-mail": 0.05, "contact": 0.05,
+	}),
+	CategoryIP: newContextRule(48, contextSampleWords, map[string]float64{
+		"服务器": 0.1, "主机": 0.1, "内网": 0.1, "公网": 0.1, "机房": 0.1, "登录": 0.05,
+		"server": 0.1, "host": 0.1, "ssh": 0.1, "node": 0.05,
+		"版本": -0.4, "version": -0.4,
+	}),
+	// 密钥规则本身依赖字段名，只用部署环境和占位符调整
+	CategoryToken: newContextRule(64, contextSampleWords, map[string]float64{
+		"生产": 0.1, "线上": 0.1, "prod": 0.1, "production": 0.1, "live": 0.05,
+		"占位": -0.4, "替换为": -0.3, "placeholder": -0.4, "your": -0.3, "redacted": -0.4,
+	}),
+	CategoryLicensePlate: newContextRule(48, contextSampleWords, map[string]float64{
+		"车牌": 0.1, "牌照": 0.1, "车辆": 0.05, "违章": 0.05, "停车": 0.05, "plate": 0.1,
+	}),
+	CategoryDate: newContextRule(48, contextSampleWords, map[string]float64{
+		"患者": 0.1, "病人": 0.1, "patient": 0.1,
+		"发布": -0.3, "更新": -0.3, "创建": -0.3, "到期": -0.3, "截止": -0.3, "签发": -0.2,
+		"released": -0.3, "updated": -0.3, "created": -0.3, "expires": -0.3, "deadline": -0.3,
+	}),
+}
+
+// contextHit 匹配附近命中的关键词
+type contextHit struct {
+	word   string
+	weight float64
+}
+
+// ScoreContext 按匹配附近的上下文关键词调整 findings 的置信度和风险，并在原因中说明命中的关键词
+// 正向和负向各取权重最大的一个关键词，重复出现不会叠加
+func ScoreContext(text string, findings []Finding) {
+	for i := range findings {
+		f := &findings[i]
+		rule := contextRules[f.Type]
+		if rule == nil || f.Start < 0 || f.End > len(text) {
+			continue
+		}
+		hits := rule.hits(text, f.Start, f.End)
+		if len(hits) == 0 {
+			continue
+		}
+
+		var boost, penalty float64
+		notes := make([]string, len(hits))
+		for j, h := range hits {
+			boost = math.Max(boost, h.weight)
+			penalty = math.Min(penalty, h.weight)
+			notes[j] = fmt.Sprintf("%s %+.2f", h.word, h.weight)
+		}
+		delta := boost + penalty
+		f.Confidence = math.Round(clampFloat(f.Confidence+delta, 0.05, 0.99)*100) / 100
+		f.Risk = int(clampFloat(math.Round(float64(f.Risk)*(1+delta)), 1, 100))
+		f.Reason += "；上下文：" + strings.Join(notes, "、")
+	}
+}
+
+// hits 返回匹配前后窗口内命中的关键词，按出现顺序去重，不包括匹配本身的文本
+func (r *contextRule) hits(text string, start, end int) []contextHit {
+	from := max(start-r.window, 0)
+	if i := strings.LastIndexByte(text[from:start], '\n'); i >= 0 {
+		from += i + 1
+	}
+	to := min(end+r.window, len(text))
+	if i := strings.IndexByte(text[end:to], '\n'); i >= 0 {
+		to = end + i
+	}
+
+	var hits []contextHit
+	seen := make(map[string]bool)
+	for _, window := range []string{text[from:start], text[end:to]} {
+		for _, m := range r.pattern.FindAllStringIndex(window, -1) {
+			// 英文关键词两侧不能紧挨字母，避免 "test" 匹配 "latest"
+			if m[0] > 0 && isASCIILetter(window[m[0]-1]) && isASCIILetter(window[m[0]]) ||
+				m[1] < len(window) && isASCIILetter(window[m[1]]) && isASCIILetter(window[m[1]-1]) {
+				continue
+			}
+			word := strings.ToLower(window[m[0]:m[1]])
+			if seen[word] {
+				continue
+			}
+			seen[word] = true
+			hits = append(hits, contextHit{word: window[m[0]:m[1]], weight: r.keywords[word]})
+		}
+	}
+	return hits
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func clampFloat(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,246 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateDetector 日期检测器（用于出生日期等敏感日期）
+type DateDetector struct {
+	BaseDetector
+}
+
+func NewDateDetector() *DateDetector {
+	return &DateDetector{BaseDetector{category: CategoryDate}}
+}
+
+const dateMonthNames = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+
+// 日期格式，分组顺序由 dateFormat.order 说明
+var dateFormats = []struct {
+	pattern *regexp.Regexp
+	order   string // y/m/d 表示年月日分组的顺序，n 表示两个数字分组的顺序需要按区域设置判断
+}{
+	// 1990年1月1日、1990 年 1 月 1 号
+	{regexp.MustCompile(`(\d{4})\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*[日号]`), "ymd"},
+	// 1990-01-01、1990/1/1、1990.01.01
+	{regexp.MustCompile(`(?:^|[^\d.])(\d{4})([-/.])(\d{1,2})([-/.])(\d{1,2})(?:$|[^\d])`), "ysmsd"},
+	// January 1, 1990、Jan. 1st 1990
+	{regexp.MustCompile(`(?i)\b` + dateMonthNames + `\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`), "mdy"},
+	// 1 January 1990、1st of Jan 1990、01-Jan-1990
+	{regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?(?:\s+of)?[\s-]+` + dateMonthNames + `,?[\s-]+(\d{4})\b`), "dmy"},
+	// 01/02/1990：日月顺序按区域设置判断
+	{regexp.MustCompile(`(?:^|[^\d.])(\d{1,2})([-/.])(\d{1,2})([-/.])(\d{4})(?:$|[^\d])`), "nsnsy"},
+}
+
+var (
+	// dateLabelPattern 出生日期关键词，匹配到的日期紧跟其后
+	dateLabelPattern = regexp.MustCompile(`(?i)(?:出生日期|出生年月日|出生年月|出生于|出生|生日|生于|birthday|date of birth|birth date|birthdate|\bDOB|\bborn(?: on)?)[\s:：=是为]*$`)
+	// dateCompactPattern 关键词后的 8 位紧凑日期，如 出生日期：19900101
+	dateCompactPattern = regexp.MustCompile(`(?i)(?:出生日期|出生|生日|birthday|date of birth|\bDOB)[\s:：=]*(\d{4})(\d{2})(\d{2})\b`)
+	// dateIdentityPattern 身份信息关键词，附近未带关键词的日期可能是出生日期
+	dateIdentityPattern = regexp.MustCompile(`(?i)姓名|名字|身份证|证件|护照|患者|病人|客户|员工|\bname\b|\bpatient\b|\bpassport\b|\bID\b|\bSSN\b`)
+)
+
+const (
+	// dateLabelWindow 出生日期关键词与日期之间允许的最大距离（字节）
+	dateLabelWindow = 40
+	// dateContextWindow 身份信息关键词与日期之间允许的最大距离（字节）
+	dateContextWindow = 60
+)
+
+// mdyRegions 习惯使用 MM/DD/YYYY 的地区
+var mdyRegions = map[string]bool{"US": true, "PH": true, "FM": true, "MH": true, "PW": true}
+
+// dateMatch 解析并校验通过的日期
+type dateMatch struct {
+	start, end int
+}
+
+func (d *DateDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	settings := SettingsFrom(ctx)
+	add := func(start, end int, confidence float64, risk int, reason string) {
+		for _, f := range findings {
+			if start < f.End && f.Start < end {
+				return
+			}
+		}
+		findings = append(findings, Finding{
+			Type:       CategoryDate,
+			Start:      start,
+			End:        end,
+			Text:       text[start:end],
+			Confidence: confidence,
+			Risk:       risk,
+			Reason:     reason,
+		})
+	}
+
+	dates := findDates(ctx, text, settings.Locale)
+
+	// 带关键词的出生日期，只检查日期前的一小段文本
+	for _, m := range dates {
+		from := m.start - dateLabelWindow
+		if from < 0 {
+			from = 0
+		}
+		if dateLabelPattern.MatchString(text[from:m.start]) {
+			add(m.start, m.end, 0.9, 60, "检测到出生日期")
+		}
+	}
+	for _, match := range dateCompactPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		if validBirthDate(text[match[2]:match[3]], text[match[4]:match[5]], text[match[6]:match[7]]) {
+			add(match[2], match[7], 0.85, 60, "检测到出生日期")
+		}
+	}
+
+	// 身份信息附近未带关键词的日期
+	if settings.ContextualDates {
+		for _, m := range dates {
+			if ctx.Err() != nil {
+				return findings
+			}
+			from := m.start - dateContextWindow
+			if from < 0 {
+				from = 0
+			}
+			to := m.end + dateContextWindow
+			if to > len(text) {
+				to = len(text)
+			}
+			if dateIdentityPattern.MatchString(text[from:to]) {
+				add(m.start, m.end, 0.5, 40, "检测到身份信息附近的日期")
+			}
+		}
+	}
+	return findings
+}
+
+// findDates 识别所有格式的日期并做日历校验，重叠时保留先匹配到的格式
+func findDates(ctx context.Context, text, locale string) []dateMatch {
+	var dates []dateMatch
+	mdy := localeUsesMDY(locale)
+	for _, format := range dateFormats {
+		for _, match := range format.pattern.FindAllStringSubmatchIndex(text, -1) {
+			if ctx.Err() != nil {
+				return dates
+			}
+			group := func(i int) string {
+				return text[match[2*i]:match[2*i+1]]
+			}
+
+			// 匹配范围从第一个分组到最后一个分组，不含格式两侧的边界字符
+			var year, month, day string
+			start, end := match[2], match[len(match)-1]
+			switch format.order {
+			case "ymd":
+				start, end = match[0], match[1]
+				year, month, day = group(1), group(2), group(3)
+			case "ysmsd":
+				// 两个分隔符必须相同
+				if group(2) != group(4) {
+					continue
+				}
+				year, month, day = group(1), group(3), group(5)
+			case "mdy":
+				month, day, year = monthNumber(group(1)), group(2), group(3)
+			case "dmy":
+				day, month, year = group(1), monthNumber(group(2)), group(3)
+			case "nsnsy":
+				if group(2) != group(4) {
+					continue
+				}
+				first, second := group(1), group(3)
+				year = group(5)
+				switch {
+				case locale == "":
+					// 未指定区域设置时任一顺序有效即可
+					month, day = first, second
+					if !validBirthDate(year, month, day) {
+						month, day = second, first
+					}
+				case mdy:
+					month, day = first, second
+				default:
+					day, month = first, second
+				}
+			}
+			if !validBirthDate(year, month, day) {
+				continue
+			}
+
+			overlaps := false
+			for _, m := range dates {
+				if start < m.end && m.start < end {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				dates = append(dates, dateMatch{start: start, end: end})
+			}
+		}
+	}
+	return dates
+}
+
+// validBirthDate 校验是真实存在的日期，且在 1900 年之后、不晚于今天
+func validBirthDate(year, month, day string) bool {
+	y, err1 := strconv.Atoi(year)
+	m, err2 := strconv.Atoi(month)
+	d, err3 := strconv.Atoi(day)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return false
+	}
+	if m < 1 || m > 12 || d < 1 || d > 31 || y < 1900 {
+		return false
+	}
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	// 2月30日等不存在的日期会被 time.Date 顺延到下个月
+	if t.Month() != time.Month(m) || t.Day() != d {
+		return false
+	}
+	return !t.After(time.Now())
+}
+
+// monthNumber 将英文月份名转换为数字
+func monthNumber(name string) string {
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	prefix := strings.ToLower(name)
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+	for i, m := range months {
+		if m == prefix {
+			return strconv.Itoa(i + 1)
+		}
+	}
+	return ""
+}
+
+// localeUsesMDY 判断区域设置是否使用 MM/DD/YYYY，只有语言没有地区时按英语习惯处理
+func localeUsesMDY(locale string) bool {
+	parts := strings.Split(strings.ReplaceAll(locale, "_", "-"), "-")
+	if len(parts) == 1 {
+		return strings.EqualFold(parts[0], "en")
+	}
+	return mdyRegions[localeRegion(locale)]
+}
+
+// localeRegion 返回区域设置中的地区代码（大写），如 zh-Hans-CN 返回 CN，没有地区时返回空字符串
+func localeRegion(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(locale, "_", "-"), "-")
+	for _, part := range parts[1:] {
+		if len(part) == 2 {
+			return strings.ToUpper(part)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// 证件子类型，输出在 Finding.DocumentType 中
+const (
+	DocumentCNID             = "cn_id"                    // 居民身份证
+	DocumentCNPassport       = "cn_passport"              // 中国护照
+	DocumentPassport         = "passport"                 // 其他护照
+	DocumentHKID             = "hkid"                     // 香港身份证
+	DocumentMacauID          = "macau_id"                 // 澳门居民身份证
+	DocumentTaiwanID         = "taiwan_id"                // 台湾身份证
+	DocumentHKMacauPermit    = "hk_macau_permit"          // 往来港澳通行证
+	DocumentTaiwanPermit     = "taiwan_compatriot_permit" // 台湾居民来往大陆通行证（台胞证）
+	DocumentHomeReturnPermit = "home_return_permit"       // 港澳居民来往内地通行证（回乡证）
+)
+
+// documentRule 一种证件号码的识别规则
+type documentRule struct {
+	docType   string
+	region    string            // 签发证件的国家或地区（ISO 3166-1）
+	name      string            // 证件名称，用于原因说明
+	pattern   *regexp.Regexp    // 号码格式
+	label     *regexp.Regexp    // 号码前的关键词，以 $ 结尾
+	check     func(string) bool // 号段、日期等格式检查，不通过的号码直接跳过
+	validate  func(string) bool // 校验码检查
+	unlabeled string            // 没有关键词时识别号码的最低级别，空表示必须有关键词
+	risk      int
+}
+
+// documentLabelWindow 关键词与号码之间允许的最大距离（字节）
+const documentLabelWindow = 64
+
+// documentLevels 检测级别由宽松到严格的顺序
+var documentLevels = map[string]int{"lenient": 1, "standard": 2, "strict": 3}
+
+// documentLabel 关键词后可以跟 "号码"、"No." 和冒号等分隔符
+func documentLabel(words string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(?:` + words + `)(?:号码|號碼|字号|字號|号|號|编号|\s*no\.?|\s*number)?[\s:：=是为#]*$`)
+}
+
+// detectDocuments 按顺序应用证件规则，与先识别的号码重叠时跳过
+// 有校验码的号码：有效时识别（有关键词 0.95，没有 0.85），无效时只在有关键词且非宽松模式下以 0.5 识别
+// 没有校验码的号码：有关键词 0.85，没有 0.7
+func detectDocuments(ctx context.Context, text, level string, category Category, rules []documentRule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		for _, match := range rule.pattern.FindAllStringIndex(text, -1) {
+			if ctx.Err() != nil {
+				return findings
+			}
+			start, end := match[0], match[1]
+			if overlapsAny(findings, start, end) || rule.check != nil && !rule.check(text[start:end]) {
+				continue
+			}
+			labeled := rule.label != nil && rule.label.MatchString(text[max(start-documentLabelWindow, 0):start])
+			if !labeled && (rule.unlabeled == "" || documentLevels[level] < documentLevels[rule.unlabeled]) {
+				continue
+			}
+
+			f := Finding{
+				Type:         category,
+				Start:        start,
+				End:          end,
+				Text:         text[start:end],
+				Risk:         rule.risk,
+				DocumentType: rule.docType,
+				Region:       rule.region,
+			}
+			if rule.validate != nil {
+				valid := rule.validate(f.Text)
+				switch {
+				case valid:
+					f.Confidence = 0.85
+					if labeled {
+						f.Confidence = 0.95
+					}
+					f.Reason = fmt.Sprintf("检测到%s（校验码有效）", rule.name)
+				case labeled && level != "lenient":
+					f.Confidence = 0.5
+					f.Risk = 50
+					f.Reason = fmt.Sprintf("检测到疑似%s（校验码无效）", rule.name)
+				default:
+					continue
+				}
+				f.Valid = &valid
+			} else {
+				f.Confidence = 0.7
+				f.Reason = fmt.Sprintf("检测到%s格式", rule.name)
+				if labeled {
+					f.Confidence = 0.85
+					f.Reason = fmt.Sprintf("检测到%s", rule.name)
+				}
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
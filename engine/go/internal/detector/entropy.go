@@ -0,0 +1,219 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// EntropyDetector 高熵字符串检测器，按香农熵、字符种类和长度识别没有固定格式的密钥
+// 类别与 TokenDetector 相同，风险更低，与其重叠时去重保留格式明确的结果
+type EntropyDetector struct {
+	BaseDetector
+}
+
+func NewEntropyDetector() *EntropyDetector {
+	return &EntropyDetector{BaseDetector{category: CategoryToken}}
+}
+
+// entropyThreshold 一组判定阈值
+type entropyThreshold struct {
+	minLen     int
+	minEntropy float64 // 每个字符的熵（bit）
+	minClasses int     // 字符种类：小写字母、大写字母、数字、符号
+}
+
+// 带密钥类字段名的取值（如 X_AUTH=...）可以放宽阈值；宽松模式不识别无字段名的字符串
+var (
+	entropyKeyedThresholds = map[string]entropyThreshold{
+		"lenient":  {minLen: 20, minEntropy: 3.5, minClasses: 2},
+		"standard": {minLen: 16, minEntropy: 3.0, minClasses: 2},
+		"strict":   {minLen: 12, minEntropy: 3.0, minClasses: 2},
+	}
+	entropyPlainThresholds = map[string]entropyThreshold{
+		"standard": {minLen: 32, minEntropy: 4.3, minClasses: 3},
+		"strict":   {minLen: 20, minEntropy: 3.5, minClasses: 2},
+	}
+)
+
+// entropyMaxLen 更长的字符串通常是编码后的数据而不是密钥
+const entropyMaxLen = 128
+
+var (
+	// 候选字符串，= 只允许作为 base64 结尾的填充
+	entropyCandidatePattern = regexp.MustCompile(`[A-Za-z0-9+/_\-]{12,}={0,2}`)
+	// 候选前的字段名，如 X_AUTH=、signing_salt: 、"token": "
+	entropyKeyPattern = regexp.MustCompile(`([A-Za-z_][\w.\-]*)["']?\s*[:=]\s*["']?$`)
+	// 密钥类字段名
+	entropySecretKeyPattern = regexp.MustCompile(`(?i)auth|salt|secret|token|key|pass|pwd|credential|cred|sign|private|session|cookie|hmac|api`)
+	// 哈希类字段名，取值是校验和而不是密钥
+	entropyHashKeyPattern = regexp.MustCompile(`(?i)^(?:h1|md5|sha\d*|.*(?:hash|checksum|integrity|digest|commit|etag|revision|resolved|shasum).*)$`)
+	// lockfile 中的 SRI 哈希，如 sha512-xxx
+	entropySRIPattern  = regexp.MustCompile(`^(?:sha(?:1|224|256|384|512)|md5)-`)
+	entropyUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	entropyHexPattern  = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
+
+// entropyKeyWindow 字段名与取值之间允许的最大距离（字节）
+const entropyKeyWindow = 64
+
+func (d *EntropyDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	for _, match := range entropyCandidatePattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		candidate := text[start:end]
+		if len(candidate) > entropyMaxLen {
+			continue
+		}
+
+		key := entropyKeyBefore(text, start)
+		keyed := key != "" && entropySecretKeyPattern.MatchString(key)
+		if entropySuppressed(text, start, candidate, key, keyed) {
+			continue
+		}
+
+		threshold, ok := entropyPlainThresholds[level]
+		if keyed {
+			threshold, ok = entropyKeyedThresholds[level]
+		}
+		if !ok || len(candidate) < threshold.minLen || charClasses(candidate) < threshold.minClasses {
+			continue
+		}
+		entropy := shannonEntropy(candidate)
+		if entropy < threshold.minEntropy {
+			continue
+		}
+
+		f := Finding{
+			Type:       CategoryToken,
+			Start:      start,
+			End:        end,
+			Text:       candidate,
+			Confidence: 0.6,
+			Risk:       70,
+			Reason:     fmt.Sprintf("检测到高熵字符串（%.1f bit/字符）", entropy),
+			RuleID:     "generic-high-entropy",
+		}
+		if keyed {
+			f.Confidence = 0.8
+			f.Risk = 80
+			f.Reason = fmt.Sprintf("检测到疑似密钥 %s（%.1f bit/字符）", key, entropy)
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// entropySuppressed 排除 UUID、git SHA、lockfile 哈希、路径和普通标识符
+func entropySuppressed(text string, start int, candidate, key string, keyed bool) bool {
+	switch {
+	case entropyUUIDPattern.MatchString(candidate):
+		return true
+	case entropySRIPattern.MatchString(candidate):
+		return true
+	case key != "" && entropyHashKeyPattern.MatchString(key):
+		return true
+	case start >= 7 && text[start-7:start] == "base64,":
+		// data: URI 中的内容
+		return true
+	case !keyed && entropyHexPattern.MatchString(candidate):
+		// 没有字段名的十六进制串几乎都是 git SHA 或文件哈希
+		return true
+	}
+	return !hasMixedSegment(candidate) || looksLikePath(candidate) || isSequential(candidate)
+}
+
+// entropyKeyBefore 返回同一行中紧挨候选字符串之前的字段名
+func entropyKeyBefore(text string, start int) string {
+	from := start - entropyKeyWindow
+	if from < 0 {
+		from = 0
+	}
+	prefix := text[from:start]
+	if i := strings.LastIndexByte(prefix, '\n'); i >= 0 {
+		prefix = prefix[i+1:]
+	}
+	if m := entropyKeyPattern.FindStringSubmatch(prefix); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// hasMixedSegment 至少有一段同时包含字母和数字；只由单词和纯数字组成的是标识符（如 user_name_2）
+func hasMixedSegment(s string) bool {
+	for _, seg := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == '/' || r == '+' || r == '='
+	}) {
+		if strings.ContainsAny(seg, "0123456789") && strings.IndexFunc(seg, func(r rune) bool {
+			return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		}) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikePath 每一段都不超过两种字符的是路径，如 src/components/v2/button
+func looksLikePath(s string) bool {
+	if !strings.Contains(s, "/") {
+		return false
+	}
+	for _, seg := range strings.Split(s, "/") {
+		if charClasses(seg) > 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSequential 一半以上相邻字符是连续的，如 abcdefg0123456789
+func isSequential(s string) bool {
+	steps := 0
+	for i := 1; i < len(s); i++ {
+		if s[i] == s[i-1]+1 {
+			steps++
+		}
+	}
+	return steps*2 > len(s)
+}
+
+// charClasses 统计字符种类：小写字母、大写字母、数字、其他符号
+func charClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z':
+			lower = 1
+		case c >= 'A' && c <= 'Z':
+			upper = 1
+		case c >= '0' && c <= '9':
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// shannonEntropy 每个字符的香农熵（bit）
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	n := float64(len(s))
+	var h float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
@@ -0,0 +1,272 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GPSDetector GPS坐标检测器
+type GPSDetector struct {
+	BaseDetector
+}
+
+func NewGPSDetector() *GPSDetector {
+	return &GPSDetector{BaseDetector{category: CategoryGPS}}
+}
+
+var (
+	// geo: URI（RFC 5870），如 geo:39.9042,116.4074
+	gpsGeoURIPattern = regexp.MustCompile(`geo:(-?\d{1,3}(?:\.\d+)?),(-?\d{1,3}(?:\.\d+)?)(?:,-?\d+(?:\.\d+)?)?`)
+	// 地图服务商链接，坐标由 gpsURLParams 在链接内部提取
+	gpsMapURLPattern = regexp.MustCompile(`https?://(?:[\w-]+\.)*(?:google\.[a-z.]+/maps|maps\.google\.[a-z.]+|goo\.gl/maps|amap\.com|map\.baidu\.com|openstreetmap\.org|maps\.apple\.com)[^\s"'<>]*`)
+	// GeoJSON 坐标数组，顺序为 [经度, 纬度]
+	gpsGeoJSONPattern = regexp.MustCompile(`"coordinates"\s*:\s*\[\s*(-?\d{1,3}(?:\.\d+)?)\s*,\s*(-?\d{1,3}(?:\.\d+)?)\s*[\],]`)
+	// 键值对形式，如 {"lat": 39.9042, "lng": 116.4074} 或 mlat=39.9042&mlon=116.4074
+	gpsKeyValuePattern = regexp.MustCompile(`(?i)\bm?lat(?:itude)?"?\s*[:=：]\s*"?(-?\d{1,3}\.\d+)"?\s*[,;&，]?\s*"?\bm?(?:lng|lon|long|longitude)"?\s*[:=：]\s*"?(-?\d{1,3}\.\d+)`)
+	// 度分秒，如 39°54'26"N 116°23'29"E 或 北纬39°54′26″ 东经116°23′29″
+	gpsDMSPattern = regexp.MustCompile(`(北纬|南纬)?\s*(\d{1,2}(?:\.\d+)?)\s*°\s*(?:(\d{1,2}(?:\.\d+)?)\s*['′]\s*(?:(\d{1,2}(?:\.\d+)?)\s*(?:''|"|″)\s*)?)?([NSns])?` +
+		`\s*[,，/]?\s*` +
+		`(东经|西经)?\s*(\d{1,3}(?:\.\d+)?)\s*°\s*(?:(\d{1,2}(?:\.\d+)?)\s*['′]\s*(?:(\d{1,2}(?:\.\d+)?)\s*(?:''|"|″)\s*)?)?([EWew])?`)
+	// 带关键词的十进制坐标，如 GPS: 39.9042, 116.4074
+	gpsLabeledPattern = regexp.MustCompile(`(?i)(?:GPS|坐标|经纬度|定位|位置|coordinates?|location|lat\s*[,/]\s*lo?ng)[\s:：=]*[(\[]?\s*(-?\d{1,3}\.\d+)\s*°?\s*([NSns])?\s*[,，/ ]\s*(-?\d{1,3}\.\d+)\s*°?\s*([EWew])?\b`)
+	// 不带关键词的十进制坐标，只有带半球标记或精度足够高时才报告
+	gpsPlainPattern = regexp.MustCompile(`(?:^|[^\w.\-])(-?\d{1,3}\.\d{2,})\s*°?\s*([NSns])?\s*[,，]\s*(-?\d{1,3}\.\d{2,})\s*°?\s*([EWew])?\b`)
+)
+
+// gpsURLParams 地图链接中携带坐标的参数，顺序不确定时两种顺序都尝试
+var gpsURLParams = []*regexp.Regexp{
+	regexp.MustCompile(`@(-?\d{1,3}\.\d+),(-?\d{1,3}\.\d+)`),
+	regexp.MustCompile(`[?&](?:q|ll|sll|query|center|destination|daddr|saddr|location|position|lnglat)=(-?\d{1,3}\.\d+)(?:,|%2C)(-?\d{1,3}\.\d+)`),
+	regexp.MustCompile(`#map=\d+/(-?\d{1,3}\.\d+)/(-?\d{1,3}\.\d+)`),
+}
+
+// gpsMinPlainPrecision 不带关键词的坐标至少需要的小数位数（约 1 米），避免把 3.1415, 2.7182 这类数字当作坐标
+const gpsMinPlainPrecision = 5
+
+func (d *GPSDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	// 按可信度从高到低识别，已识别的范围不再重复报告
+	add := func(start, end, precision int, confidence float64, reason string) {
+		for _, f := range findings {
+			if start < f.End && f.Start < end {
+				return
+			}
+		}
+		p := precision
+		findings = append(findings, Finding{
+			Type:       CategoryGPS,
+			Start:      start,
+			End:        end,
+			Text:       text[start:end],
+			Confidence: confidence,
+			Risk:       70,
+			Reason:     reason,
+			Precision:  &p,
+		})
+	}
+
+	for _, match := range gpsGeoURIPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		lat, lon := text[match[2]:match[3]], text[match[4]:match[5]]
+		if validCoordinates(lat, lon) {
+			add(match[0], match[1], coordinatePrecision(lat, lon), 0.95, "检测到 geo: URI 坐标")
+		}
+	}
+
+	for _, match := range gpsMapURLPattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		url := text[match[0]:match[1]]
+		for _, param := range gpsURLParams {
+			for _, sub := range param.FindAllStringSubmatchIndex(url, -1) {
+				a, b := url[sub[2]:sub[3]], url[sub[4]:sub[5]]
+				if validCoordinates(a, b) || validCoordinates(b, a) {
+					add(match[0]+sub[2], match[0]+sub[5], coordinatePrecision(a, b), 0.9, "检测到地图链接中的坐标")
+				}
+			}
+		}
+	}
+
+	for _, match := range gpsGeoJSONPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		lon, lat := text[match[2]:match[3]], text[match[4]:match[5]]
+		if validCoordinates(lat, lon) {
+			add(match[2], match[5], coordinatePrecision(lat, lon), 0.9, "检测到 GeoJSON 坐标")
+		}
+	}
+
+	for _, match := range gpsKeyValuePattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		// 纬度和经度分别替换，保留字段名
+		lat, lon := text[match[2]:match[3]], text[match[4]:match[5]]
+		if validCoordinates(lat, lon) {
+			add(match[2], match[3], decimalPlaces(lat), 0.9, "检测到纬度字段")
+			add(match[4], match[5], decimalPlaces(lon), 0.9, "检测到经度字段")
+		}
+	}
+
+	for _, match := range gpsDMSPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return text[match[2*i]:match[2*i+1]]
+		}
+		// 度分秒格式必须标明两个坐标的半球，避免把角度当作坐标
+		latHemi, lonHemi := group(1)+group(5), group(6)+group(10)
+		if latHemi == "" || lonHemi == "" {
+			continue
+		}
+		lat, latPrecision, ok := parseDMS(group(2), group(3), group(4))
+		if !ok {
+			continue
+		}
+		lon, lonPrecision, ok := parseDMS(group(7), group(8), group(9))
+		if !ok {
+			continue
+		}
+		if strings.ContainsAny(latHemi, "Ss南") {
+			lat = -lat
+		}
+		if strings.ContainsAny(lonHemi, "Ww西") {
+			lon = -lon
+		}
+		if !validLatLon(lat, lon) {
+			continue
+		}
+		start, end := trimSpaceRange(text, match[0], match[1])
+		add(start, end, min(latPrecision, lonPrecision), 0.9, "检测到度分秒格式的GPS坐标")
+	}
+
+	for _, match := range gpsLabeledPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		if lat, lon, ok := parseDecimalPair(text, match); ok {
+			add(match[2], match[1], coordinatePrecision(lat, lon), 0.85, "检测到GPS坐标")
+		}
+	}
+
+	for _, match := range gpsPlainPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		lat, lon, ok := parseDecimalPair(text, match)
+		if !ok {
+			continue
+		}
+		precision := coordinatePrecision(lat, lon)
+		hemispheres := match[4] >= 0 && match[8] >= 0
+		switch {
+		case hemispheres:
+			add(match[2], match[1], precision, 0.75, "检测到带半球标记的GPS坐标格式")
+		case level != "lenient" && precision >= gpsMinPlainPrecision:
+			add(match[2], match[1], precision, 0.6, "检测到GPS坐标格式")
+		}
+	}
+	return findings
+}
+
+// parseDecimalPair 解析十进制坐标对，分组依次为纬度、纬度半球、经度、经度半球
+// 带半球标记时按标记确定顺序和符号；否则两种顺序任一有效即可（国内常见经度在前）
+func parseDecimalPair(text string, match []int) (string, string, bool) {
+	lat, lon := text[match[2]:match[3]], text[match[6]:match[7]]
+	latHemi, lonHemi := "", ""
+	if match[4] >= 0 {
+		latHemi = text[match[4]:match[5]]
+	}
+	if match[8] >= 0 {
+		lonHemi = text[match[8]:match[9]]
+	}
+	if latHemi == "" && lonHemi == "" {
+		return lat, lon, validCoordinates(lat, lon) || validCoordinates(lon, lat)
+	}
+	// 半球标记与负号不能同时出现
+	if (latHemi != "" && strings.HasPrefix(lat, "-")) || (lonHemi != "" && strings.HasPrefix(lon, "-")) {
+		return lat, lon, false
+	}
+	return lat, lon, validCoordinates(lat, lon)
+}
+
+// parseDMS 将度分秒转换为十进制度数，返回等效的小数位数
+// 精确到度时为度数的小数位数，精确到分时约为 2 位，精确到秒时约为 4 位
+func parseDMS(deg, minutes, seconds string) (float64, int, bool) {
+	value, err := strconv.ParseFloat(deg, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	precision := decimalPlaces(deg)
+	if minutes != "" {
+		m, err := strconv.ParseFloat(minutes, 64)
+		if err != nil || m >= 60 {
+			return 0, 0, false
+		}
+		value += m / 60
+		precision = 2 + decimalPlaces(minutes)
+	}
+	if seconds != "" {
+		s, err := strconv.ParseFloat(seconds, 64)
+		if err != nil || s >= 60 {
+			return 0, 0, false
+		}
+		value += s / 3600
+		precision = 4 + decimalPlaces(seconds)
+	}
+	return value, precision, true
+}
+
+// validCoordinates 校验十进制纬度和经度
+func validCoordinates(lat, lon string) bool {
+	latValue, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return false
+	}
+	lonValue, err := strconv.ParseFloat(lon, 64)
+	if err != nil {
+		return false
+	}
+	return validLatLon(latValue, lonValue)
+}
+
+// validLatLon 纬度 -90 到 90，经度 -180 到 180，排除 (0, 0)
+func validLatLon(lat, lon float64) bool {
+	if lat == 0 && lon == 0 {
+		return false
+	}
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+// coordinatePrecision 坐标对的精度取两者中较低的小数位数
+func coordinatePrecision(lat, lon string) int {
+	return min(decimalPlaces(lat), decimalPlaces(lon))
+}
+
+func decimalPlaces(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// trimSpaceRange 去掉匹配范围两端的空白
+func trimSpaceRange(text string, start, end int) (int, int) {
+	for start < end && strings.ContainsRune(" \t\r\n", rune(text[start])) {
+		start++
+	}
+	for end > start && strings.ContainsRune(" \t\r\n", rune(text[end-1])) {
+		end--
+	}
+	return start, end
+}
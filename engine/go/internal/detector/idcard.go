@@ -0,0 +1,135 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IDCardDetector 身份证号检测器（GB 11643）
+type IDCardDetector struct {
+	BaseDetector
+}
+
+func NewIDCardDetector() *IDCardDetector {
+	return &IDCardDetector{BaseDetector{category: CategoryIDCard}}
+}
+
+var idCardPattern = regexp.MustCompile(`\b\d{17}[\dXx]\b|\b\d{15}\b`)
+
+// idCardProvinces 行政区划代码的省级前两位（GB/T 2260），83 为台湾居民居住证
+var idCardProvinces = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true,
+	"21": true, "22": true, "23": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true,
+	"50": true, "51": true, "52": true, "53": true, "54": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "81": true, "82": true, "83": true,
+}
+
+// idCardWeights ISO 7064 MOD 11-2 的加权因子
+var idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// idCardCheckChars 余数对应的校验码
+const idCardCheckChars = "10X98765432"
+
+func (d *IDCardDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	matches := idCardPattern.FindAllStringIndex(text, -1)
+	for _, match := range matches {
+		if ctx.Err() != nil {
+			return findings
+		}
+		matchedText := text[match[0]:match[1]]
+		// 不能全是相同数字
+		if isRepeatingDigits(matchedText[:len(matchedText)-1]) {
+			continue
+		}
+		// 税号、注册号等关键词后通过企业证照校验的号码由 CompanyIDDetector 处理
+		if companyIDClaimed(text, match[0], matchedText) {
+			continue
+		}
+
+		var failed []string
+		if !idCardRegionValid(matchedText) {
+			failed = append(failed, "地区码")
+		}
+		if !idCardBirthValid(matchedText) {
+			failed = append(failed, "出生日期")
+		}
+
+		var finding Finding
+		if len(matchedText) == 15 {
+			// 一代身份证没有校验位，地区码和出生日期都必须有效
+			if len(failed) > 0 {
+				continue
+			}
+			finding = Finding{Confidence: 0.8, Risk: 90, Reason: "检测到15位旧版身份证号（地区码、出生日期有效）"}
+		} else {
+			if !idCardChecksumValid(matchedText) {
+				failed = append(failed, "校验位")
+			}
+			// 宽松只接受完全有效的号码，标准允许一项不通过，严格允许两项不通过
+			allowed := 1
+			switch level {
+			case "lenient":
+				allowed = 0
+			case "strict":
+				allowed = 2
+			}
+			if len(failed) > allowed {
+				continue
+			}
+			switch len(failed) {
+			case 0:
+				finding = Finding{Confidence: 0.95, Risk: 90, Reason: "检测到18位身份证号（校验位、地区码、出生日期均有效）"}
+			case 1:
+				finding = Finding{Confidence: 0.6, Risk: 70, Reason: fmt.Sprintf("检测到疑似18位身份证号（%s无效）", failed[0])}
+			default:
+				finding = Finding{Confidence: 0.35, Risk: 50, Reason: fmt.Sprintf("检测到疑似18位身份证号（%s无效）", strings.Join(failed, "、"))}
+			}
+		}
+
+		valid := len(failed) == 0
+		finding.Type = CategoryIDCard
+		finding.Start = match[0]
+		finding.End = match[1]
+		finding.Text = matchedText
+		finding.Valid = &valid
+		finding.DocumentType = DocumentCNID
+		finding.Region = "CN"
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// idCardRegionValid 校验省级行政区划代码，并排除地市和区县代码为 00 的情况
+func idCardRegionValid(id string) bool {
+	return idCardProvinces[id[:2]] && id[2:6] != "0000"
+}
+
+// idCardBirthValid 校验出生日期是真实存在且不晚于今天的日期
+// 18位号码的出生日期为 YYYYMMDD，15位号码为 YYMMDD（19YY 年）
+func idCardBirthValid(id string) bool {
+	birth := id[6:14]
+	if len(id) == 15 {
+		birth = "19" + id[6:12]
+	}
+	t, err := time.Parse("20060102", birth)
+	if err != nil {
+		return false
+	}
+	return t.Year() >= 1900 && !t.After(time.Now())
+}
+
+// idCardChecksumValid 按 ISO 7064 MOD 11-2 校验18位号码的最后一位
+func idCardChecksumValid(id string) bool {
+	sum := 0
+	for i, w := range idCardWeights {
+		sum += int(id[i]-'0') * w
+	}
+	return strings.ToUpper(id[17:]) == string(idCardCheckChars[sum%11])
+}
@@ -0,0 +1,251 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// IPDetector IP地址检测器，支持 IPv4、IPv6（含压缩形式、zone）、CIDR 网段和 IPv4 映射地址
+type IPDetector struct {
+	BaseDetector
+}
+
+func NewIPDetector() *IPDetector {
+	return &IPDetector{BaseDetector{category: CategoryIP}}
+}
+
+// IP 地址分类
+const (
+	IPClassCorporate     = "corporate"     // 配置的企业内网网段
+	IPClassPublic        = "public"        // 公网地址
+	IPClassPrivate       = "private"       // 私有地址（RFC 1918、IPv6 ULA、运营商级 NAT）
+	IPClassLinkLocal     = "link_local"    // 链路本地地址
+	IPClassLoopback      = "loopback"      // 环回地址
+	IPClassDocumentation = "documentation" // 文档示例地址（RFC 5737、RFC 3849）
+	IPClassReserved      = "reserved"      // 未指定、组播和广播地址
+)
+
+// ipClassRisk 各分类的风险等级
+var ipClassRisk = map[string]int{
+	IPClassCorporate:     80,
+	IPClassPublic:        60,
+	IPClassPrivate:       40,
+	IPClassLinkLocal:     20,
+	IPClassLoopback:      10,
+	IPClassDocumentation: 5,
+	IPClassReserved:      5,
+}
+
+var ipClassNames = map[string]string{
+	IPClassCorporate:     "企业内网地址",
+	IPClassPublic:        "公网地址",
+	IPClassPrivate:       "私有地址",
+	IPClassLinkLocal:     "链路本地地址",
+	IPClassLoopback:      "环回地址",
+	IPClassDocumentation: "文档示例地址",
+	IPClassReserved:      "保留地址",
+}
+
+var (
+	// IPv4 地址或网段，数值范围由 netip 校验
+	ipv4Pattern = regexp.MustCompile(`\d{1,3}(?:\.\d{1,3}){3}(?:/\d{1,2})?`)
+	// IPv6 候选，如 2001:db8::1、fe80::1%eth0、::ffff:192.0.2.1、2001:db8::/32，格式由 netip 校验
+	ipv6Pattern = regexp.MustCompile(`(?i)(?:[0-9a-f]{0,4}:){2,7}(?:\d{1,3}(?:\.\d{1,3}){3}|[0-9a-f]{1,4})?(?:%[0-9a-z_.\-]+)?(?:/\d{1,3})?`)
+	// ipVersionPattern 版本号关键词，紧跟其后的点分数字不是 IP
+	ipVersionPattern = regexp.MustCompile(`(?i)(?:版本号?|version|ver\.?|release|build)[\s:：=]*$`)
+
+	// 文档示例网段
+	ipDocumentationRanges = []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	// 运营商级 NAT 共享地址（RFC 6598），按私有地址处理
+	ipSharedRange = netip.MustParsePrefix("100.64.0.0/10")
+)
+
+// ipVersionWindow 版本号关键词与数字之间允许的最大距离（字节）
+const ipVersionWindow = 16
+
+func (d *IPDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	corporate := SettingsFrom(ctx).CorporateRanges
+	add := func(start, end int, prefix netip.Prefix, isPrefix bool, kind string) {
+		for _, f := range findings {
+			if start < f.End && f.Start < end {
+				return
+			}
+		}
+		class := classifyIP(prefix, corporate)
+		if !ipClassEnabled(class, level) {
+			return
+		}
+		if isPrefix {
+			kind += "网段"
+		} else {
+			kind += "地址"
+		}
+		findings = append(findings, Finding{
+			Type:       CategoryIP,
+			Start:      start,
+			End:        end,
+			Text:       text[start:end],
+			Confidence: 0.9,
+			Risk:       ipClassRisk[class],
+			Reason:     fmt.Sprintf("检测到%s（%s）", kind, ipClassNames[class]),
+			IPClass:    class,
+		})
+	}
+
+	// 先识别 IPv6，避免 IPv4 映射地址中的 IPv4 部分被重复报告
+	for _, match := range ipv6Pattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		if !ipBoundary(text, start, end, ":") {
+			continue
+		}
+		candidate := text[start:end]
+		// 至少包含一个十进制数字，避免把 C++/Rust 中的 a::b 当作地址
+		if !strings.ContainsAny(candidate, "0123456789") {
+			continue
+		}
+		prefix, isPrefix, ok := parseIP(candidate)
+		if !ok || !prefix.Addr().Is6() {
+			continue
+		}
+		kind := "IPv6"
+		if prefix.Addr().Is4In6() {
+			kind = "IPv4映射的IPv6"
+		}
+		add(start, end, prefix, isPrefix, kind)
+	}
+
+	for _, match := range ipv4Pattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[0], match[1]
+		// 排除版本号（如 1.2.3.4.5、v1.2.3.4）
+		if !ipBoundary(text, start, end, ".") {
+			continue
+		}
+		from := start - ipVersionWindow
+		if from < 0 {
+			from = 0
+		}
+		if ipVersionPattern.MatchString(text[from:start]) {
+			continue
+		}
+		prefix, isPrefix, ok := parseIP(text[start:end])
+		if !ok {
+			// 前缀长度无效时按单个地址处理
+			if i := strings.IndexByte(text[start:end], '/'); i >= 0 {
+				end = start + i
+				prefix, isPrefix, ok = parseIP(text[start:end])
+			}
+			if !ok {
+				continue
+			}
+		}
+		add(start, end, prefix, isPrefix, "IPv4")
+	}
+	return findings
+}
+
+// ipBoundary 匹配两侧不能紧挨字母、数字或以 sep 分隔的更多数字，IPv4 前可以是冒号（如 host:10.0.0.1）
+func ipBoundary(text string, start, end int, sep string) bool {
+	isWord := func(c byte) bool {
+		return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	if start > 0 && (isWord(text[start-1]) || strings.IndexByte(sep+".", text[start-1]) >= 0) {
+		return false
+	}
+	if end < len(text) {
+		if isWord(text[end]) || text[end] == ':' && sep == ":" {
+			return false
+		}
+		if strings.IndexByte(sep, text[end]) >= 0 && end+1 < len(text) && isWord(text[end+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseIP 解析地址或 CIDR 网段，单个地址按全长前缀返回
+func parseIP(s string) (netip.Prefix, bool, bool) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix, true, err == nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, false, false
+	}
+	addr = addr.WithZone("")
+	return netip.PrefixFrom(addr, addr.BitLen()), false, true
+}
+
+// classifyIP 对地址或网段分类，IPv4 映射地址按其 IPv4 地址分类
+func classifyIP(prefix netip.Prefix, corporate []netip.Prefix) string {
+	if prefix.Addr().Is4In6() {
+		bits := prefix.Bits() - 96
+		if bits < 0 {
+			bits = 0
+		}
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), bits)
+	}
+	for _, r := range corporate {
+		if r.Overlaps(prefix) {
+			return IPClassCorporate
+		}
+	}
+
+	addr := prefix.Masked().Addr()
+	switch {
+	case addr.IsLoopback():
+		return IPClassLoopback
+	case addr.IsUnspecified(), addr.IsMulticast(), addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}):
+		return IPClassReserved
+	case addr.IsLinkLocalUnicast():
+		return IPClassLinkLocal
+	case addr.IsPrivate(), ipSharedRange.Contains(addr):
+		return IPClassPrivate
+	}
+	for _, r := range ipDocumentationRanges {
+		if r.Contains(addr) {
+			return IPClassDocumentation
+		}
+	}
+	return IPClassPublic
+}
+
+// ipClassEnabled 宽松模式只报告公网、私有和企业内网地址，标准模式增加环回和链路本地地址，严格模式报告全部
+func ipClassEnabled(class, level string) bool {
+	switch class {
+	case IPClassCorporate, IPClassPublic, IPClassPrivate:
+		return true
+	case IPClassLoopback, IPClassLinkLocal:
+		return level != "lenient"
+	default:
+		return level == "strict"
+	}
+}
+
+// ParseIPRanges 解析 CIDR 网段列表，单个地址视为只包含该地址的网段
+func ParseIPRanges(ranges []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, r := range ranges {
+		prefix, _, ok := parseIP(strings.TrimSpace(r))
+		if !ok {
+			return nil, fmt.Errorf("invalid IP range %q", r)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,303 @@
+package detector
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NameDetector 姓名检测器（中文姓名）
+// 关键词后的姓名在所有模式下识别；未带关键词的姓名按姓氏词典、名字常用字和前后的动词、称谓打分，
+// 只在 standard 和 strict 下按各自的阈值报告
+type NameDetector struct {
+	BaseDetector
+}
+
+func NewNameDetector() *NameDetector {
+	return &NameDetector{BaseDetector{category: CategoryName}}
+}
+
+// nameLabelPattern 姓名关键词后的 2-4 个汉字，如 姓名：张三、紧急联系人 李四
+var nameLabelPattern = regexp.MustCompile(`(?i)(?:姓名|名字|联系人|成员|配偶|父亲|母亲|同学)[\s:：]+([\x{4e00}-\x{9fa5}]{2,4})`)
+
+// nameUnlabeledThresholds 未带关键词的姓名需要达到的分数，lenient 不识别
+var nameUnlabeledThresholds = map[string]float64{
+	"standard": 0.7,
+	"strict":   0.5,
+}
+
+var (
+	// 常见单姓，不含 "和"、"都"、"由" 这类几乎只作虚词使用的姓
+	nameSurnames = runeSet("王李张刘陈杨黄赵吴周徐孙马朱胡郭何高林罗郑梁谢宋唐许韩冯邓曹彭曾肖田董袁潘于蒋蔡余杜叶程苏魏吕丁任沈姚卢姜崔钟谭陆汪范金石廖贾夏韦付方白邹孟熊秦邱江尹薛闫段雷侯龙史陶黎贺顾毛郝龚邵万钱严覃武戴莫孔向汤常温康施文牛樊葛邢安齐易乔伍庞颜倪庄聂章鲁岳翟殷詹申欧耿关兰焦俞左柳甘祝包宁尚符舒阮柯纪梅童凌毕单季裴霍涂成苗谷盛曲翁冉骆蓝路游辛靳管柴蒙鲍华喻祁蒲房滕屈饶解牟艾尤阳时穆农司卓古吉缪简车项连芦麦褚娄窦戚岑景党宫费卜冷晏席卫米柏宗瞿桂佟应臧闵苟邬边卞姬仇栾隋刁巫寇桑郎甄丛仲虞敖巩明佘池查麻苑迟邝封谈匡鞠惠荆冀郁胥栗燕楚鄢谌奚粟冼蔺闻厉伊仝郜阚屠朴禹漆卿狄晋芮扈晁阙鹿邸雍辜裘亓邰赫杭况逯茹诸慕亢嵇湛戎勾茅揭尉冶檀昝衡尧")
+	// 同时是常用字的姓，不能单独和称谓组成姓名（如 向经理汇报），打分时扣分
+	nameAmbiguousSurnames = runeSet("于向时常文方安成明白金石江田路关程包宁尚万华高龙连管解应")
+	// 复姓
+	nameCompoundSurnames = stringSet("欧阳", "司马", "上官", "诸葛", "东方", "皇甫", "尉迟", "公孙", "慕容", "长孙", "宇文", "司徒", "令狐", "夏侯", "轩辕",
+		"端木", "独孤", "南宫", "西门", "百里", "呼延", "闻人", "万俟", "澹台", "公冶", "太史", "申屠", "钟离", "左丘", "东郭", "赫连", "拓跋",
+		"司空", "闾丘", "亓官", "微生", "梁丘", "乐正", "漆雕", "濮阳", "淳于", "单于", "太叔", "巫马", "公西", "谷梁", "鲜于", "司寇")
+	// 名字常用字
+	nameGivenChars = runeSet("伟芳娜秀英敏静丽强磊军洋勇艳杰娟涛明超兰霞平刚桂华玉萍红建文辉力鹏飞斌宇浩凯健俊帆帅旭宁亮林波云峰鑫晨阳婷雪琳晶颖慧莉倩璐瑶佳欣怡梦嘉子涵轩博昊然诺睿泽思雨萱琪晴悦彤馨蕾妍晓小海龙志国荣成永春东新光天清金福祥庆生德兴宏毅诚鸿振宝昌武卫中义凤琴梅菊兵虹青玲燕丹蓉蓓君雯莹露茜瑞岩松柏楠彬晖豪诗梓奕铭皓哲熙宸逸辰弘泓安乐嫣淑贞婉婕媛")
+	// 不会出现在名字中的字（虚词、代词、时间方位词等）
+	nameStopChars = runeSet("的了是在说和跟与就都也还又把被给对从向到讲问叫让请我你他她它们这那个些么吗呢吧啊哦嗯不没很太更最年月日时分号上下里外前后来去过着得地要会能已经将而或及等")
+	// 不是姓名的词，包括以姓氏开头的常用词
+	nameStopWords = stringSet("某某", "测试", "示例", "用户", "管理员", "系统", "客户", "同事", "大家", "我们", "他们", "对方", "本人",
+		"马上", "高兴", "高度", "高级", "高效", "黄金", "周末", "周围", "周期", "周年", "林业", "方法", "方面", "方向", "方式", "方案", "方便",
+		"程序", "程度", "文件", "文化", "文章", "文档", "文本", "文字", "安全", "安装", "安排", "金额", "金融", "金属", "路由", "路径", "路线",
+		"常见", "常用", "常规", "向量", "于是", "任何", "任务", "任意", "关于", "关系", "关键", "关注", "关闭", "华为", "余额", "钱包",
+		"白天", "白色", "石油", "江湖", "成功", "成本", "成为", "成员", "许多", "许可", "陈述", "明天", "明白", "明确", "明显", "时间",
+		"时候", "时代", "包括", "包含", "包装", "尚未", "万一", "史上", "应该", "应用", "曾经", "严重", "严格", "范围", "左右", "易于",
+		"顾客", "顾问", "何时", "何况", "谢谢", "叶子", "施工", "毕业", "毕竟", "单位", "单独", "季度", "温度", "康复", "齐全", "符合",
+		"连接", "连续", "车辆", "项目", "简单", "简历", "管理", "解决", "解释", "费用", "卫生", "宗旨", "查询", "查看", "迟到", "封装",
+		"谈话", "夏天", "申请", "欧元", "焦虑", "付款", "付费", "陆续", "段落", "雷达", "武器", "颜色", "庄园", "章节", "游戏", "辛苦",
+		"司机", "古代", "农业", "阳光", "尤其", "龙头", "宁可", "冷静", "景点", "宫殿", "米饭", "凌晨", "纪律", "纪念", "曲线", "谷歌",
+		"盛大", "董事", "孙子", "牛奶", "蓝色", "房间", "房子", "费心", "党员", "秦朝", "熊猫", "江南", "苏州", "沈阳", "武汉")
+)
+
+// 未带关键词的姓名前后的上下文及其分数
+var (
+	// 紧挨姓名之前的介词和动词，如 跟张伟确认、感谢李娜
+	namePrefixWords = []string{"跟", "和", "与", "同", "给", "找", "问", "叫", "请", "让", "告诉", "通知", "联系", "转告", "抄送", "感谢", "谢谢",
+		"麻烦", "委托", "拜托", "交给", "发给", "转给", "是", "由", "被", "把", "对", "向", "@"}
+	// 紧跟姓名之后的称谓，姓名可以只有姓，如 王经理
+	nameTitleWords = []string{"先生", "女士", "小姐", "老师", "医生", "大夫", "护士", "律师", "教授", "博士", "经理", "总监", "主管", "主任",
+		"总裁", "董事长", "总经理", "部长", "处长", "科长", "局长", "院长", "校长", "厂长", "所长", "书记", "同学", "师傅", "阿姨", "叔叔",
+		"警官", "老板", "组长", "队长", "班长", "会计", "秘书", "总", "工"}
+	// 紧跟姓名之后的动词，如 张伟说、李娜确认
+	nameVerbWords = []string{"说", "表示", "认为", "提到", "确认", "同意", "负责", "回复", "发来", "打来", "通知", "建议", "反馈", "要求",
+		"汇报", "提出", "发现", "签字", "审批", "处理", "安排", "介绍", "答应", "打电话", "来电"}
+	// 姓名之后的个人信息，如 张伟的电话
+	namePossessiveWords = []string{"的电话", "的手机", "的邮箱", "的身份证", "的地址", "的微信", "的账号", "的生日"}
+)
+
+// 打分权重
+const (
+	nameBaseScore       = 0.3
+	namePrefixScore     = 0.2
+	nameTitleScore      = 0.4
+	nameVerbScore       = 0.2
+	namePossessiveScore = 0.3
+	nameGivenCharScore  = 0.1 // 每个名字常用字
+	nameCompoundScore   = 0.1
+	nameAmbiguousScore  = -0.1
+	nameJoinedScore     = -0.1 // 前面紧挨其他汉字
+)
+
+// nameCandidate 未带关键词的候选姓名
+type nameCandidate struct {
+	start, end int
+	score      float64
+	signals    []string
+}
+
+func (d *NameDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	overlaps := func(start, end int) bool {
+		for _, f := range findings {
+			if start < f.End && f.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, match := range nameLabelPattern.FindAllStringSubmatchIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := match[2], match[3]
+		if nameStopWords[text[start:end]] {
+			continue
+		}
+		findings = append(findings, Finding{
+			Type:       CategoryName,
+			Start:      start,
+			End:        end,
+			Text:       text[start:end],
+			Confidence: 0.8,
+			Risk:       50,
+			Reason:     "检测到姓名信息",
+		})
+	}
+
+	threshold, ok := nameUnlabeledThresholds[level]
+	if !ok {
+		return findings
+	}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !nameSurnames[r] && !nameCompoundSurnames[prefixRunes(text[i:], 2)] {
+			i += size
+			continue
+		}
+		if ctx.Err() != nil {
+			return findings
+		}
+		c, ok := bestNameCandidate(text, i)
+		if !ok || c.score < threshold || overlaps(c.start, c.end) {
+			i += size
+			continue
+		}
+		findings = append(findings, Finding{
+			Type:       CategoryName,
+			Start:      c.start,
+			End:        c.end,
+			Text:       text[c.start:c.end],
+			Confidence: math.Round(math.Min(c.score, 0.95)*100) / 100,
+			Risk:       40,
+			Reason:     "检测到未带关键词的姓名（" + strings.Join(c.signals, "、") + "）",
+		})
+		i = c.end
+	}
+	return findings
+}
+
+// bestNameCandidate 以 start 处的姓氏组成姓名，在名字为 0-2 个字的候选中取分数最高的
+// 只有姓没有名时必须紧跟称谓；单姓加两字名必须有前置词、称谓、动词等上下文，避免 李明天去 这样的句子
+func bestNameCandidate(text string, start int) (nameCandidate, bool) {
+	surname := prefixRunes(text[start:], 2)
+	compound := nameCompoundSurnames[surname]
+	if !compound {
+		surname = prefixRunes(text[start:], 1)
+	}
+	first, _ := utf8.DecodeRuneInString(surname)
+	ambiguous := !compound && nameAmbiguousSurnames[first]
+
+	base := nameBaseScore
+	var baseSignals []string
+	if compound {
+		base += nameCompoundScore
+		baseSignals = append(baseSignals, "复姓")
+	}
+	if ambiguous {
+		base += nameAmbiguousScore
+	}
+	prefixed := false
+	if w := suffixWord(text[:start], namePrefixWords); w != "" {
+		base += namePrefixScore
+		baseSignals = append(baseSignals, "前置词“"+w+"”")
+		prefixed = true
+	} else if r, _ := utf8.DecodeLastRuneInString(text[:start]); unicode.Is(unicode.Han, r) {
+		base += nameJoinedScore
+	}
+
+	var best nameCandidate
+	found := false
+	for n := 0; n <= 2; n++ {
+		end := start + len(surname)
+		score := base
+		signals := append([]string(nil), baseSignals...)
+		valid := true
+		for k := 0; k < n; k++ {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !unicode.Is(unicode.Han, r) || nameStopChars[r] {
+				valid = false
+				break
+			}
+			if nameGivenChars[r] {
+				score += nameGivenCharScore
+			}
+			end += size
+		}
+		if !valid {
+			break
+		}
+		if n > 0 && (nameStopWords[text[start:end]] || nameStopWords[prefixRunes(text[start:], 2)]) {
+			continue
+		}
+
+		rest := text[end:]
+		contextual := prefixed
+		switch title := nameTitle(rest); {
+		case title != "":
+			score += nameTitleScore
+			signals = append(signals, "称谓“"+title+"”")
+			contextual = true
+		case n == 0:
+			continue
+		default:
+			if w := prefixWord(rest, namePossessiveWords); w != "" {
+				score += namePossessiveScore
+				signals = append(signals, "“"+w+"”")
+				contextual = true
+			} else if w := prefixWord(rest, nameVerbWords); w != "" {
+				score += nameVerbScore
+				signals = append(signals, "动词“"+w+"”")
+				contextual = true
+			}
+		}
+		if n == 0 && ambiguous || n == 2 && !compound && !contextual {
+			continue
+		}
+		if !found || score > best.score {
+			best = nameCandidate{start: start, end: end, score: score, signals: signals}
+			found = true
+		}
+	}
+	if found && len(best.signals) == 0 {
+		best.signals = []string{"姓氏"}
+	}
+	return best, found
+}
+
+// nameTitle 返回 s 开头的称谓，单字称谓（王总、李工）之后不能紧跟其他汉字，避免匹配 张总结
+func nameTitle(s string) string {
+	title := prefixWord(s, nameTitleWords)
+	if utf8.RuneCountInString(title) == 1 {
+		rest := s[len(title):]
+		if r, _ := utf8.DecodeRuneInString(rest); unicode.Is(unicode.Han, r) && prefixWord(rest, nameVerbWords) == "" {
+			return ""
+		}
+	}
+	return title
+}
+
+// prefixWord 返回 s 开头最长的词
+func prefixWord(s string, words []string) string {
+	best := ""
+	for _, w := range words {
+		if len(w) > len(best) && strings.HasPrefix(s, w) {
+			best = w
+		}
+	}
+	return best
+}
+
+// suffixWord 返回 s 结尾最长的词
+func suffixWord(s string, words []string) string {
+	best := ""
+	for _, w := range words {
+		if len(w) > len(best) && strings.HasSuffix(s, w) {
+			best = w
+		}
+	}
+	return best
+}
+
+// prefixRunes 返回 s 的前 n 个字符
+func prefixRunes(s string, n int) string {
+	end := 0
+	for i := 0; i < n && end < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+	}
+	return s[:end]
+}
+
+func runeSet(chars string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, r := range chars {
+		set[r] = true
+	}
+	return set
+}
+
+func stringSet(items ...string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
@@ -0,0 +1,141 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// NationalIDDetector 居民身份证以外的身份证件检测器：香港身份证、澳门居民身份证和台湾身份证
+// 类别与 IDCardDetector 相同，通过 Finding.DocumentType 区分
+type NationalIDDetector struct {
+	BaseDetector
+}
+
+func NewNationalIDDetector() *NationalIDDetector {
+	return &NationalIDDetector{BaseDetector{category: CategoryIDCard}}
+}
+
+var (
+	hkidLabel   = documentLabel(`香港身份证|香港身份證|身份证|身份證|HKID|HKIC|Hong\s*Kong\s*ID(?:\s*card)?`)
+	macauLabel  = documentLabel(`澳门身份证|澳門身份證|澳门居民身份证|澳門居民身份證|BIR|Macau\s*ID(?:\s*card)?`)
+	taiwanLabel = documentLabel(`台湾身份证|臺灣身分證|台灣身分證|身分证|身分證|身份证|身份證|统一编号|統一編號|Taiwan\s*ID(?:\s*card)?`)
+)
+
+// nationalIDRules 同一证件的括号格式没有关键词也能识别，去掉括号的写法必须有关键词
+var nationalIDRules = []documentRule{
+	// 香港身份证：1-2 位字母 + 6 位数字 + 括号内的校验码，如 A123456(3)
+	{
+		docType:   DocumentHKID,
+		region:    "HK",
+		name:      "香港身份证号",
+		pattern:   regexp.MustCompile(`\b[A-Z]{1,2}\d{6}\([0-9A]\)`),
+		label:     hkidLabel,
+		validate:  hkidValid,
+		unlabeled: "lenient",
+		risk:      90,
+	},
+	{
+		docType:  DocumentHKID,
+		region:   "HK",
+		name:     "香港身份证号",
+		pattern:  regexp.MustCompile(`\b[A-Z]{1,2}\d{6}[0-9A]\b`),
+		label:    hkidLabel,
+		validate: hkidValid,
+		risk:     90,
+	},
+	// 台湾身份证：户籍地字母 + 性别码（1 男 2 女，8、9 为新式居留证）+ 7 位数字 + 校验码
+	{
+		docType:   DocumentTaiwanID,
+		region:    "TW",
+		name:      "台湾身份证号",
+		pattern:   regexp.MustCompile(`\b[A-Z][1289]\d{8}\b`),
+		label:     taiwanLabel,
+		validate:  taiwanIDValid,
+		unlabeled: "standard",
+		risk:      90,
+	},
+	// 澳门居民身份证：首位 1、5、7、8 + 6 位数字 + 括号内的数字，没有公开的校验算法
+	{
+		docType:   DocumentMacauID,
+		region:    "MO",
+		name:      "澳门身份证号",
+		pattern:   regexp.MustCompile(`\b[1578]\d{6}\(\d\)`),
+		label:     macauLabel,
+		unlabeled: "standard",
+		risk:      90,
+	},
+	{
+		docType: DocumentMacauID,
+		region:  "MO",
+		name:    "澳门身份证号",
+		pattern: regexp.MustCompile(`\b[1578]\d{7}\b`),
+		label:   macauLabel,
+		risk:    90,
+	},
+}
+
+func (d *NationalIDDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	return detectDocuments(ctx, text, level, CategoryIDCard, nationalIDRules)
+}
+
+// hkidValid 香港身份证校验码：单字母前缀的第一位按空格计 36，字母 A-Z 计 10-35，
+// 按 9 到 2 加权求和，11 - 和 mod 11 为校验码，10 为 A，11 为 0
+func hkidValid(id string) bool {
+	id = strings.NewReplacer("(", "", ")", "").Replace(id)
+	if len(id) == 8 {
+		id = " " + id
+	}
+	if len(id) != 9 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		var v int
+		switch c := id[i]; {
+		case c == ' ':
+			v = 36
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		default:
+			return false
+		}
+		sum += v * (9 - i)
+	}
+	var check byte
+	switch c := 11 - sum%11; c {
+	case 10:
+		check = 'A'
+	case 11:
+		check = '0'
+	default:
+		check = byte('0' + c)
+	}
+	return id[8] == check
+}
+
+// taiwanIDLetters 台湾身份证首字母对应的两位数字（I、O、W、X、Y、Z 等为后加的县市，不按字母顺序）
+var taiwanIDLetters = map[byte]int{
+	'A': 10, 'B': 11, 'C': 12, 'D': 13, 'E': 14, 'F': 15, 'G': 16, 'H': 17, 'I': 34,
+	'J': 18, 'K': 19, 'L': 20, 'M': 21, 'N': 22, 'O': 35, 'P': 23, 'Q': 24, 'R': 25,
+	'S': 26, 'T': 27, 'U': 28, 'V': 29, 'W': 32, 'X': 30, 'Y': 31, 'Z': 33,
+}
+
+// taiwanIDValid 台湾身份证校验：字母两位数的十位 ×1、个位 ×9，后 8 位依次 ×8 到 ×1，加上校验码后能被 10 整除
+func taiwanIDValid(id string) bool {
+	if len(id) != 10 {
+		return false
+	}
+	code, ok := taiwanIDLetters[id[0]]
+	if !ok {
+		return false
+	}
+	sum := code/10 + code%10*9
+	for i := 1; i < 9; i++ {
+		sum += int(id[i]-'0') * (9 - i)
+	}
+	sum += int(id[9] - '0')
+	return sum%10 == 0
+}
@@ -0,0 +1,91 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// PassportDetector 护照和出入境通行证检测器：中国护照、往来港澳通行证、回乡证、台胞证和带关键词的其他护照
+// 通过 Finding.DocumentType 区分证件
+type PassportDetector struct {
+	BaseDetector
+}
+
+func NewPassportDetector() *PassportDetector {
+	return &PassportDetector{BaseDetector{category: CategoryPassport}}
+}
+
+var passportLabel = documentLabel(`护照|護照|passport`)
+
+// passportRules 按顺序识别，通行证的号码格式更具体，放在通用护照之前
+var passportRules = []documentRule{
+	// 中国普通护照：E + 8 位（第 2 位可以是字母，不使用 I、O）或旧版 G + 8 位数字
+	{
+		docType:   DocumentCNPassport,
+		region:    "CN",
+		name:      "中国护照号",
+		pattern:   regexp.MustCompile(`\b(?:E[A-HJ-NP-Z0-9]\d{7}|G\d{8})\b`),
+		label:     passportLabel,
+		unlabeled: "standard",
+		risk:      85,
+	},
+	// 外交（D/DE）、公务（S/SE）、公务普通（P/PE）护照，与产品编号容易混淆，必须有关键词
+	{
+		docType: DocumentCNPassport,
+		region:  "CN",
+		name:    "中国护照号",
+		pattern: regexp.MustCompile(`\b[DSP]E?\d{7}\b`),
+		label:   passportLabel,
+		risk:    85,
+	},
+	// 往来港澳通行证：C（旧版本式）或 W（电子）+ 1 位字母或数字 + 7 位数字
+	{
+		docType:   DocumentHKMacauPermit,
+		region:    "CN",
+		name:      "往来港澳通行证号",
+		pattern:   regexp.MustCompile(`\b[CW][0-9A-HJ-NP-Z]\d{7}\b`),
+		label:     documentLabel(`往来港澳通行证|往來港澳通行證|港澳通行证|港澳通行證|双程证|雙程證|Exit-Entry\s*Permit`),
+		unlabeled: "strict",
+		risk:      85,
+	},
+	// 回乡证：H（香港）或 M（澳门）+ 8 位数字，旧版后面还有 2 位换证次数
+	{
+		docType:   DocumentHomeReturnPermit,
+		region:    "CN",
+		name:      "回乡证号",
+		pattern:   regexp.MustCompile(`\b[HM]\d{8}(?:\d{2})?\b`),
+		label:     documentLabel(`回乡证|回鄉證|港澳居民来往内地通行证|港澳居民來往內地通行證|Home\s*Return\s*Permit`),
+		unlabeled: "strict",
+		risk:      85,
+	},
+	// 台胞证：8 位数字（五年期），旧版 10 位数字后可带括号内的签发次数，纯数字必须有关键词
+	{
+		docType: DocumentTaiwanPermit,
+		region:  "CN",
+		name:    "台胞证号",
+		pattern: regexp.MustCompile(`\b\d{8}(?:\d{2})?\b(?:\([A-Z0-9]\))?`),
+		label:   documentLabel(`台胞证|台胞證|台湾居民来往大陆通行证|臺灣居民來往大陸通行證|MTP`),
+		risk:    85,
+	},
+	// 其他国家和地区的护照：6-9 位字母数字，必须有关键词
+	{
+		docType: DocumentPassport,
+		name:    "护照号",
+		pattern: regexp.MustCompile(`\b[A-Z0-9]{6,9}\b`),
+		label:   passportLabel,
+		risk:    85,
+	},
+}
+
+func (d *PassportDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	findings := detectDocuments(ctx, text, level, CategoryPassport, passportRules)
+	// 护照号至少包含一位数字，排除 "护照 PASSPORT" 之类的单词
+	kept := findings[:0]
+	for _, f := range findings {
+		if strings.ContainsAny(f.Text, "0123456789") {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
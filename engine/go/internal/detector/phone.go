@@ -0,0 +1,381 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PhoneDetector 电话号码检测器
+// 带国际前缀（+ 或 00）的号码按国家代码识别，国内格式的号码按默认地区识别
+type PhoneDetector struct {
+	BaseDetector
+}
+
+func NewPhoneDetector() *PhoneDetector {
+	return &PhoneDetector{BaseDetector{category: CategoryPhone}}
+}
+
+// 号码类型
+const (
+	LineTypeMobile            = "mobile"
+	LineTypeFixedLine         = "fixed_line"
+	LineTypeFixedLineOrMobile = "fixed_line_or_mobile" // 北美号码无法从号段区分固话和手机
+	LineTypeTollFree          = "toll_free"
+)
+
+// phoneRegion 一个国家或地区的号码规则
+type phoneRegion struct {
+	region      string // ISO 3166-1 地区代码
+	countryCode string // 国际电话区号
+	trunk       string // 国内长途前缀，国际格式中省略
+	types       []phoneType
+}
+
+// phoneType 一类号码的规则，pattern 匹配不含国家代码和长途前缀的号码
+type phoneType struct {
+	lineType  string
+	pattern   *regexp.Regexp
+	needTrunk bool // 国内格式必须带长途前缀（如英国 020、国内座机 010）
+}
+
+func phoneRule(lineType, pattern string, needTrunk bool) phoneType {
+	return phoneType{lineType: lineType, pattern: regexp.MustCompile(`^(?:` + pattern + `)$`), needTrunk: needTrunk}
+}
+
+// phoneRegions 支持的地区，同一地区内按顺序匹配，号段重叠时先列出的类型优先
+var phoneRegions = []phoneRegion{
+	{"CN", "86", "0", []phoneType{
+		phoneRule(LineTypeMobile, `1[3-9]\d{9}`, false),
+		phoneRule(LineTypeTollFree, `[48]00\d{7}`, false),
+		phoneRule(LineTypeFixedLine, `(?:10|2\d)\d{8}|[3-9]\d{9,10}`, true),
+	}},
+	{"US", "1", "1", []phoneType{
+		phoneRule(LineTypeTollFree, `8(?:00|33|44|55|66|77|88)[2-9]\d{6}`, false),
+		phoneRule(LineTypeFixedLineOrMobile, `[2-9]\d{2}[2-9]\d{6}`, false),
+	}},
+	{"GB", "44", "0", []phoneType{
+		phoneRule(LineTypeMobile, `7[1-9]\d{8}`, true),
+		phoneRule(LineTypeTollFree, `80[08]\d{6,7}`, true),
+		phoneRule(LineTypeFixedLine, `[12]\d{8,9}`, true),
+	}},
+	{"HK", "852", "", []phoneType{
+		phoneRule(LineTypeMobile, `[5-79]\d{7}`, false),
+		phoneRule(LineTypeTollFree, `800\d{6}`, false),
+		phoneRule(LineTypeFixedLine, `[23]\d{7}`, false),
+	}},
+	{"SG", "65", "", []phoneType{
+		phoneRule(LineTypeMobile, `[89]\d{7}`, false),
+		phoneRule(LineTypeTollFree, `1800\d{7}`, false),
+		phoneRule(LineTypeFixedLine, `6\d{7}`, false),
+	}},
+	{"DE", "49", "0", []phoneType{
+		phoneRule(LineTypeMobile, `1[5-7]\d{8,9}`, true),
+		phoneRule(LineTypeTollFree, `800\d{7,12}`, true),
+		phoneRule(LineTypeFixedLine, `[2-9]\d{5,10}`, true),
+	}},
+	{"FR", "33", "0", []phoneType{
+		phoneRule(LineTypeMobile, `[67]\d{8}`, true),
+		phoneRule(LineTypeTollFree, `80\d{7}`, true),
+		phoneRule(LineTypeFixedLine, `[1-59]\d{8}`, true),
+	}},
+	{"NL", "31", "0", []phoneType{
+		phoneRule(LineTypeMobile, `6\d{8}`, true),
+		phoneRule(LineTypeTollFree, `800\d{4,7}`, true),
+		phoneRule(LineTypeFixedLine, `[1-578]\d{8}`, true),
+	}},
+	{"ES", "34", "", []phoneType{
+		phoneRule(LineTypeMobile, `[67]\d{8}`, false),
+		phoneRule(LineTypeTollFree, `900\d{6}`, false),
+		phoneRule(LineTypeFixedLine, `[89]\d{8}`, false),
+	}},
+	{"IT", "39", "", []phoneType{
+		phoneRule(LineTypeMobile, `3\d{8,9}`, false),
+		phoneRule(LineTypeTollFree, `80[03]\d{3,6}`, false),
+		phoneRule(LineTypeFixedLine, `0\d{5,10}`, false),
+	}},
+	{"IE", "353", "0", []phoneType{
+		phoneRule(LineTypeMobile, `8[3-9]\d{7}`, true),
+		phoneRule(LineTypeTollFree, `1800\d{6}`, true),
+		phoneRule(LineTypeFixedLine, `[1-9]\d{6,9}`, true),
+	}},
+}
+
+// DefaultPhoneRegions 未配置时识别国内格式号码的默认地区
+var DefaultPhoneRegions = []string{"CN"}
+
+// phoneLineRisk 各号码类型的风险等级
+var phoneLineRisk = map[string]int{
+	LineTypeMobile:            60,
+	LineTypeFixedLineOrMobile: 60,
+	LineTypeFixedLine:         50,
+	LineTypeTollFree:          30,
+}
+
+var phoneLineNames = map[string]string{
+	LineTypeMobile:            "手机号",
+	LineTypeFixedLineOrMobile: "电话号码",
+	LineTypeFixedLine:         "固定电话",
+	LineTypeTollFree:          "免费电话",
+}
+
+// phoneCandidatePattern 可能是电话号码的数字串，数字组之间允许空格、横线、点和括号分隔
+var phoneCandidatePattern = regexp.MustCompile(`(?:\+\s?|\b00)?\(?\d+(?:[ \-.()]{1,3}\d+)*`)
+
+var phoneDigitGroup = regexp.MustCompile(`\d+`)
+
+const (
+	// phoneMaxDigits E.164 号码（含国家代码）的最大位数
+	phoneMaxDigits = 15
+	// phoneMinPlainDigits 不带分隔符的国内格式号码至少需要的位数，更短的纯数字在严格模式下才识别
+	phoneMinPlainDigits = 10
+)
+
+// phoneMatch 识别到的号码
+type phoneMatch struct {
+	region   *phoneRegion
+	lineType string
+}
+
+func (d *PhoneDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	regions := SettingsFrom(ctx).PhoneRegions
+	if len(regions) == 0 {
+		regions = DefaultPhoneRegions
+	}
+
+	for _, candidate := range phoneCandidatePattern.FindAllStringIndex(text, -1) {
+		if ctx.Err() != nil {
+			return findings
+		}
+		start, end := candidate[0], candidate[1]
+		if !phoneBoundary(text, start, end) {
+			continue
+		}
+		s := text[start:end]
+		international := strings.HasPrefix(s, "+") || strings.HasPrefix(s, "00")
+		groups := phoneDigitGroup.FindAllStringIndex(s, -1)
+		if international && strings.HasPrefix(s, "00") {
+			// 00 前缀与后面的国家代码在同一个数字组中
+			groups[0][0] += 2
+		}
+
+		// 从左到右取尽可能多的数字组组成号码，识别失败时去掉末尾的组再试
+		// 组合的总位数不超过 phoneMaxDigits，避免很长的数字串逐段尝试所有组合
+		for i := 0; i < len(groups); {
+			if ctx.Err() != nil {
+				return findings
+			}
+			last, n := i, 0
+			for last < len(groups) && n+groups[last][1]-groups[last][0] <= phoneMaxDigits {
+				n += groups[last][1] - groups[last][0]
+				last++
+			}
+			matched := false
+			for j := last; j > i; j-- {
+				if ctx.Err() != nil {
+					return findings
+				}
+				digits := phoneDigits(s, groups[i:j])
+				var m phoneMatch
+				var ok bool
+				if international && i == 0 {
+					m, ok = matchInternational(digits, level)
+				} else {
+					m, ok = matchNational(digits, s, groups[i:j], regions, level)
+				}
+				if !ok {
+					continue
+				}
+
+				from := start + groups[i][0]
+				if international && i == 0 {
+					from = start
+				} else if from > start && text[from-1] == '(' {
+					from--
+				}
+				findings = append(findings, newPhoneFinding(text, from, start+groups[j-1][1], m, international && i == 0))
+				i, matched = j, true
+				break
+			}
+			if !matched {
+				i++
+			}
+		}
+	}
+	return findings
+}
+
+func newPhoneFinding(text string, start, end int, m phoneMatch, international bool) Finding {
+	f := Finding{
+		Type:       CategoryPhone,
+		Start:      start,
+		End:        end,
+		Text:       text[start:end],
+		Confidence: 0.9,
+		Risk:       60,
+		Reason:     "检测到国际电话号码格式",
+	}
+	if m.region == nil {
+		// 未收录的国家代码只按 E.164 长度判断
+		f.Confidence = 0.6
+		return f
+	}
+	f.CountryCode = m.region.countryCode
+	f.LineType = m.lineType
+	f.Risk = phoneLineRisk[m.lineType]
+	name := phoneLineNames[m.lineType]
+	switch {
+	case m.region.region == "CN" && !international:
+		f.Reason = "检测到中国" + name + "格式"
+	case international:
+		f.Reason = fmt.Sprintf("检测到%s %s（+%s）", m.region.region, name, m.region.countryCode)
+	default:
+		f.Reason = fmt.Sprintf("检测到%s %s", m.region.region, name)
+	}
+	if m.lineType != LineTypeMobile && m.lineType != LineTypeFixedLineOrMobile {
+		f.Confidence = 0.75
+	}
+	return f
+}
+
+// matchInternational 按国家代码识别带国际前缀的号码，代码为 1 到 3 位，不存在互为前缀的代码
+func matchInternational(digits, level string) (phoneMatch, bool) {
+	if len(digits) > phoneMaxDigits {
+		return phoneMatch{}, false
+	}
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		code, number := digits[:n], digits[n:]
+		for i := range phoneRegions {
+			region := &phoneRegions[i]
+			if region.countryCode != code {
+				continue
+			}
+			// 国际格式不带长途前缀，但常见 +44 (0)20 这样的写法
+			if region.trunk != "" && strings.HasPrefix(number, region.trunk) {
+				if lineType, ok := region.match(number[len(region.trunk):]); ok {
+					return phoneMatch{region: region, lineType: lineType}, true
+				}
+			}
+			if lineType, ok := region.match(number); ok {
+				return phoneMatch{region: region, lineType: lineType}, true
+			}
+		}
+	}
+	if level == "strict" && len(digits) >= 8 && digits[0] != '0' {
+		return phoneMatch{}, true
+	}
+	return phoneMatch{}, false
+}
+
+// matchNational 按默认地区识别国内格式的号码
+func matchNational(digits, s string, groups [][]int, regions []string, level string) (phoneMatch, bool) {
+	if len(digits) > phoneMaxDigits || phoneLooksLikeOther(s, groups) {
+		return phoneMatch{}, false
+	}
+	// 较短的号码必须带分隔符，避免把订单号等纯数字当作电话
+	if len(groups) == 1 && len(digits) < phoneMinPlainDigits && level != "strict" {
+		return phoneMatch{}, false
+	}
+	for _, name := range regions {
+		region := findPhoneRegion(name)
+		if region == nil {
+			continue
+		}
+		if region.trunk != "" && strings.HasPrefix(digits, region.trunk) {
+			if lineType, ok := region.match(digits[len(region.trunk):]); ok && phoneLineEnabled(lineType, level) {
+				return phoneMatch{region: region, lineType: lineType}, true
+			}
+		}
+		if lineType, ok := region.match(digits); ok && phoneLineEnabled(lineType, level) {
+			if region.trunk == "" || !region.needsTrunk(lineType) {
+				return phoneMatch{region: region, lineType: lineType}, true
+			}
+		}
+	}
+	return phoneMatch{}, false
+}
+
+// match 返回号码类型，number 不含国家代码和长途前缀
+func (r *phoneRegion) match(number string) (string, bool) {
+	for _, t := range r.types {
+		if t.pattern.MatchString(number) {
+			return t.lineType, true
+		}
+	}
+	return "", false
+}
+
+// needsTrunk 该类型的号码在国内格式中是否必须带长途前缀
+func (r *phoneRegion) needsTrunk(lineType string) bool {
+	for _, t := range r.types {
+		if t.lineType == lineType {
+			return t.needTrunk
+		}
+	}
+	return false
+}
+
+// phoneLineEnabled 宽松模式下国内格式只识别手机号
+func phoneLineEnabled(lineType, level string) bool {
+	return level != "lenient" || lineType == LineTypeMobile || lineType == LineTypeFixedLineOrMobile
+}
+
+func findPhoneRegion(name string) *phoneRegion {
+	for i := range phoneRegions {
+		if strings.EqualFold(phoneRegions[i].region, name) {
+			return &phoneRegions[i]
+		}
+	}
+	return nil
+}
+
+// ValidPhoneRegion 判断是否支持该地区的国内格式号码
+func ValidPhoneRegion(region string) bool {
+	return findPhoneRegion(region) != nil
+}
+
+// PhoneRegionNames 返回支持的地区代码
+func PhoneRegionNames() []string {
+	names := make([]string, len(phoneRegions))
+	for i, r := range phoneRegions {
+		names[i] = r.region
+	}
+	return names
+}
+
+func phoneDigits(s string, groups [][]int) string {
+	var b strings.Builder
+	for _, g := range groups {
+		b.WriteString(s[g[0]:g[1]])
+	}
+	return b.String()
+}
+
+// phoneLooksLikeOther 排除日期（2024-01-15、15.01.2024）和 IPv4 地址这类同样由数字组构成的内容
+func phoneLooksLikeOther(s string, groups [][]int) bool {
+	lengths := make([]int, len(groups))
+	for i, g := range groups {
+		lengths[i] = g[1] - g[0]
+	}
+	switch len(groups) {
+	case 3:
+		return lengths[0] == 4 && lengths[1] <= 2 && lengths[2] <= 2 ||
+			lengths[0] <= 2 && lengths[1] <= 2 && lengths[2] == 4
+	case 4:
+		return ipv4Pattern.MatchString(s[groups[0][0]:groups[3][1]]) && strings.Count(s[groups[0][0]:groups[3][1]], ".") == 3
+	}
+	return false
+}
+
+// phoneBoundary 号码两侧不能紧挨字母或数字
+func phoneBoundary(text string, start, end int) bool {
+	isAlnum := func(c byte) bool {
+		return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	if start > 0 && isAlnum(text[start-1]) {
+		return false
+	}
+	return end >= len(text) || !isAlnum(text[end])
+}
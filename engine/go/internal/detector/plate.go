@@ -0,0 +1,93 @@
+package detector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// LicensePlateDetector 机动车号牌检测器（GA 36），支持普通号牌、新能源号牌、挂/学/警/港/澳/领号牌和使馆号牌
+type LicensePlateDetector struct {
+	BaseDetector
+}
+
+func NewLicensePlateDetector() *LicensePlateDetector {
+	return &LicensePlateDetector{BaseDetector{category: CategoryLicensePlate}}
+}
+
+// plateProvinces 省级行政区简称
+const plateProvinces = "京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼"
+
+// 号牌中不使用字母 I 和 O，省份简称和发牌机关代号后可以有分隔点或空格，如 京A·12345、粤B D12345
+// 换行不作为分隔符，与 sanitizer 中 maskPlate 去掉的字符一致
+const plateSeparator = `[·•・ \t\-]?`
+
+// plateFormats 按顺序识别，重叠时保留先识别的格式
+var plateFormats = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	// 小型新能源：D（纯电动）或 F（非纯电动）开头，后 4 位为数字；大型新能源：5 位数字后跟 D 或 F
+	{regexp.MustCompile(`[` + plateProvinces + `][A-HJ-NP-Z]` + plateSeparator + `(?:[DF][A-HJ-NP-Z0-9]\d{4}|\d{5}[DF])`), "检测到新能源汽车号牌"},
+	// 挂车、教练车、警车、港澳入出境车、领馆车
+	{regexp.MustCompile(`[` + plateProvinces + `][A-HJ-NP-Z]` + plateSeparator + `[A-HJ-NP-Z0-9]{4}[挂学警港澳领]`), "检测到专用号牌"},
+	// 普通号牌
+	{regexp.MustCompile(`[` + plateProvinces + `][A-HJ-NP-Z]` + plateSeparator + `[A-HJ-NP-Z0-9]{5}`), "检测到机动车号牌"},
+	// 使馆号牌：使 + 3 位国家代码 + 3 位顺序号
+	{regexp.MustCompile(`使` + plateSeparator + `\d{3}` + plateSeparator + `\d{3}`), "检测到使馆号牌"},
+}
+
+func (d *LicensePlateDetector) Detect(ctx context.Context, text string, level string) []Finding {
+	var findings []Finding
+	for _, format := range plateFormats {
+		for _, match := range format.pattern.FindAllStringIndex(text, -1) {
+			if ctx.Err() != nil {
+				return findings
+			}
+			start, end := match[0], match[1]
+			// 后面紧跟字母或数字时不是完整的号牌
+			if end < len(text) && isPlateChar(text[end]) {
+				continue
+			}
+			plate := text[start:end]
+			if !plateSerialValid(plate) || overlapsAny(findings, start, end) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Type:       CategoryLicensePlate,
+				Start:      start,
+				End:        end,
+				Text:       plate,
+				Confidence: 0.85,
+				Risk:       60,
+				Reason:     format.reason,
+			})
+		}
+	}
+	return findings
+}
+
+// plateSerialValid 普通号牌的 5 位序号中最多有 2 个字母
+func plateSerialValid(plate string) bool {
+	var serial []byte
+	for i := 0; i < len(plate); i++ {
+		if isPlateChar(plate[i]) {
+			serial = append(serial, plate[i])
+		}
+	}
+	// 第一个字母是发牌机关代号，新能源和专用号牌的序号不是 5 位字母数字
+	if strings.HasPrefix(plate, "使") || len(serial) != 6 {
+		return true
+	}
+	letters := 0
+	for _, c := range serial[1:] {
+		if c >= 'A' && c <= 'Z' {
+			letters++
+		}
+	}
+	return letters <= 2
+}
+
+func isPlateChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
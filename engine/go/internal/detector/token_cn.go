@@ -0,0 +1,39 @@
+package detector
+
+import "regexp"
+
+// cnCloudTokenRules 国内云厂商和办公平台
+// 没有固定前缀的 AK/SK 只在带厂商关键词的字段中识别，如 HUAWEICLOUD_SDK_SK=、qiniu.secret_key:
+// SK 规则排在 AK 之前，避免 secret_access_key 被识别为 AK
+var cnCloudTokenRules = []tokenRule{
+	// 阿里云
+	{"aliyun-access-key-id", regexp.MustCompile(`\bLTAI[A-Za-z0-9]{12,20}\b`), 85, "检测到阿里云AccessKey ID", "LTAI"},
+	{"aliyun-access-key-secret", regexp.MustCompile(`(?i)(?:(?:aliyun|alibaba|alicloud)[\w.\-]*?secret[_\-]?(?:key)?|access[_\-]?key[_\-]?secret)["']?\s*[:=：]\s*["']?([A-Za-z0-9]{30})\b`), 95, "检测到阿里云AccessKey Secret", ""},
+	// 腾讯云
+	{"tencent-secret-id", regexp.MustCompile(`\bAKID[A-Za-z0-9]{13,40}\b`), 85, "检测到腾讯云SecretId", "AKID"},
+	{"tencent-secret-key", regexp.MustCompile(`(?i)(?:tencent|qcloud|\bcos)[\w.\-]*?secret[_\-]?key["']?\s*[:=：]\s*["']?([A-Za-z0-9]{32})\b`), 95, "检测到腾讯云SecretKey", ""},
+	// 华为云
+	{"huawei-secret-key", regexp.MustCompile(`(?i)(?:huawei|hwcloud|\bobs)[\w.\-]*?(?:secret[_\-]?(?:access[_\-]?)?key|sk)["']?\s*[:=：]\s*["']?([A-Za-z0-9]{40})\b`), 95, "检测到华为云SK", ""},
+	{"huawei-access-key", regexp.MustCompile(`(?i)(?:huawei|hwcloud|\bobs)[\w.\-]*?(?:access[_\-]?key(?:[_\-]?id)?|ak)["']?\s*[:=：]\s*["']?([A-Z0-9]{20})\b`), 85, "检测到华为云AK", ""},
+	// 百度智能云
+	{"baidu-bce-secret-key", regexp.MustCompile(`(?i)(?:\bbce|baidu)[\w.\-]*?(?:secret[_\-]?(?:access[_\-]?)?key|sk)["']?\s*[:=：]\s*["']?([0-9a-zA-Z]{32})\b`), 95, "检测到百度智能云SecretKey", ""},
+	{"baidu-bce-access-key", regexp.MustCompile(`(?i)(?:\bbce|baidu)[\w.\-]*?(?:access[_\-]?key(?:[_\-]?id)?|ak)["']?\s*[:=：]\s*["']?([0-9a-zA-Z]{32})\b`), 85, "检测到百度智能云AccessKey", ""},
+	// 火山引擎
+	{"volcengine-access-key-id", regexp.MustCompile(`\bAKLT[A-Za-z0-9_\-]{20,60}`), 85, "检测到火山引擎AccessKey ID", "AKLT"},
+	{"volcengine-secret-access-key", regexp.MustCompile(`(?i)volc(?:engine)?[\w.\-]*?(?:secret[_\-]?(?:access[_\-]?)?key|sk)["']?\s*[:=：]\s*["']?([A-Za-z0-9+/=]{40,64})`), 95, "检测到火山引擎SecretAccessKey", ""},
+	// 七牛云
+	{"qiniu-access-key", regexp.MustCompile(`(?i)qiniu[\w.\-]*?(?:access[_\-]?key|ak)["']?\s*[:=：]\s*["']?([A-Za-z0-9_\-]{40})\b`), 85, "检测到七牛云AccessKey", ""},
+	{"qiniu-secret-key", regexp.MustCompile(`(?i)qiniu[\w.\-]*?(?:secret[_\-]?key|sk)["']?\s*[:=：]\s*["']?([A-Za-z0-9_\-]{40})\b`), 95, "检测到七牛云SecretKey", ""},
+
+	// 企业微信、钉钉、飞书机器人 Webhook
+	{"wecom-webhook-url", regexp.MustCompile(`https://qyapi\.weixin\.qq\.com/cgi-bin/webhook/send\?key=[0-9a-fA-F\-]{36}`), 80, "检测到企业微信机器人Webhook", "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key="},
+	{"dingtalk-webhook-url", regexp.MustCompile(`https://oapi\.dingtalk\.com/robot/send\?access_token=[0-9a-fA-F]{64}`), 80, "检测到钉钉机器人Webhook", "https://oapi.dingtalk.com/robot/send?access_token="},
+	{"dingtalk-bot-secret", regexp.MustCompile(`\bSEC[0-9a-f]{64}\b`), 85, "检测到钉钉机器人加签密钥", "SEC"},
+	{"feishu-webhook-url", regexp.MustCompile(`https://open\.feishu\.cn/open-apis/bot/v2/hook/[0-9a-fA-F\-]{36}`), 80, "检测到飞书机器人Webhook", "https://open.feishu.cn/open-apis/bot/v2/hook/"},
+	{"lark-webhook-url", regexp.MustCompile(`https://open\.larksuite\.com/open-apis/bot/v2/hook/[0-9a-fA-F\-]{36}`), 80, "检测到Lark机器人Webhook", "https://open.larksuite.com/open-apis/bot/v2/hook/"},
+
+	// 微信、钉钉、飞书应用密钥
+	{"wechat-app-secret", regexp.MustCompile(`(?i)(?:wechat|weixin|\bwx)[\w.\-]*?secret["']?\s*[:=：]\s*["']?([0-9a-f]{32})\b`), 90, "检测到微信AppSecret", ""},
+	{"dingtalk-app-secret", regexp.MustCompile(`(?i)ding(?:talk)?[\w.\-]*?secret["']?\s*[:=：]\s*["']?([A-Za-z0-9_\-]{64})\b`), 90, "检测到钉钉AppSecret", ""},
+	{"feishu-app-secret", regexp.MustCompile(`(?i)(?:feishu|lark)[\w.\-]*?secret["']?\s*[:=：]\s*["']?([A-Za-z0-9]{32})\b`), 90, "检测到飞书App Secret", ""},
+}
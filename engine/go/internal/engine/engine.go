@@ -1,6 +1,13 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
 	"github.com/prompt-sanitizer/engine/internal/detector"
 	"github.com/prompt-sanitizer/engine/internal/sanitizer"
 	"github.com/prompt-sanitizer/engine/pkg/types"
@@ -11,6 +18,16 @@ const Version = "0.1.0"
 // Engine 清洗引擎
 type Engine struct {
 	detectors []detector.Detector
+
+	// 配置文件中的命名配置，只在启动时通过 UseConfig 设置
+	profiles       map[string]types.Profile
+	defaultProfile string
+
+	limits Limits
+
+	// 企业内网网段，通过 ctx 中的检测设置传给 IP 检测器
+	corporateRanges []netip.Prefix
+	phoneRegions    []string
 }
 
 // NewEngine 创建引擎实例
@@ -20,9 +37,11 @@ func NewEngine() *Engine {
 			detector.NewPhoneDetector(),
 			detector.NewEmailDetector(),
 			detector.NewIDCardDetector(),
+			detector.NewNationalIDDetector(),
 			detector.NewIPDetector(),
 			detector.NewDomainDetector(),
 			detector.NewTokenDetector(),
+			detector.NewEntropyDetector(),
 			detector.NewPasswordDetector(),
 			detector.NewPrivateKeyDetector(),
 			detector.NewBankCardDetector(),
@@ -36,27 +55,34 @@ func NewEngine() *Engine {
 			detector.NewDatabaseConnDetector(),
 			detector.NewNameDetector(),
 			detector.NewDateDetector(),
+			detector.NewCompanyIDDetector(),
+			detector.NewLicensePlateDetector(),
+			detector.NewForeignIDDetector(),
 		},
+		limits: Limits{MaxInputSize: DefaultMaxInputSize, Timeout: DefaultTimeout},
 	}
 }
 
 // Process 处理清洗请求
-func (e *Engine) Process(req *types.Request) (*types.Response, error) {
-	// 确定启用的检测器
-	enabledDetectors := e.getEnabledDetectors(req.EnabledCategories)
-
-	// 执行检测
-	allFindings := make([]detector.Finding, 0)
-	for _, det := range enabledDetectors {
-		findings := det.Detect(req.Text, req.Level)
-		allFindings = append(allFindings, findings...)
+// ctx 被取消时返回 ctx 的错误；超时则返回已完成检测器的部分结果，Partial 为 true
+func (e *Engine) Process(ctx context.Context, req *types.Request) (*types.Response, error) {
+	// 合并命名配置并校验请求，拒绝不支持的取值，避免静默回退到默认行为
+	req, warnings, err := e.Resolve(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkInputSize(req.Text); err != nil {
+		return nil, err
 	}
 
-	// 应用白名单过滤
-	allFindings = e.applyAllowlist(allFindings, req.Text, req.Allowlist)
+	ctx, cancel := e.withTimeout(ctx, req)
+	defer cancel()
 
-	// 去重（相同位置和类型）
-	allFindings = e.deduplicateFindings(allFindings)
+	// 执行检测
+	allFindings, incomplete := e.detect(ctx, req, req.Text)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return nil, ctx.Err()
+	}
 
 	// 如果是标注模式，不执行清洗
 	var sanitizedText string
@@ -64,26 +90,16 @@ func (e *Engine) Process(req *types.Request) (*types.Response, error) {
 
 	if req.Mode == "annotate" {
 		sanitizedText = req.Text
-		// 转换为响应格式但不替换
-		convertedFindings = make([]types.Finding, len(allFindings))
-		for i, f := range allFindings {
-			convertedFindings[i] = types.Finding{
-				Type:               string(f.Type),
-				Start:              f.Start,
-				End:                f.End,
-				Confidence:         f.Confidence,
-				Risk:               f.Risk,
-				Replacement:        f.Text, // 标注模式下不替换
-				ReplacementPreview: e.getPreview(f.Text, f.Risk),
-				Reason:             f.Reason,
-			}
-		}
+		convertedFindings = e.annotateFindings(allFindings)
 	} else {
 		// 执行清洗
 		san := sanitizer.NewSanitizer(req.Strategy, allFindings)
 		sanitizedText, convertedFindings = san.Sanitize(req.Text)
 	}
 
+	// 补充字符偏移、UTF-16 偏移和行列号，供不同语言的前端定位
+	newPositionTracker().fill(req.Text, 0, convertedFindings)
+
 	// 计算统计信息
 	stats := e.calculateStats(convertedFindings)
 
@@ -96,9 +112,124 @@ func (e *Engine) Process(req *types.Request) (*types.Response, error) {
 		Stats:         stats,
 		RiskScore:     riskScore,
 		Version:       Version,
+		Warnings:      warnings,
+
+		Partial:             len(incomplete) > 0,
+		IncompleteDetectors: incomplete,
 	}, nil
 }
 
+// detect 对文本执行检测、白名单过滤和去重
+// 同时返回因 ctx 结束而未完成的检测类别
+func (e *Engine) detect(ctx context.Context, req *types.Request, text string) ([]detector.Finding, []string) {
+	// 确定启用的检测器
+	enabledDetectors := e.getEnabledDetectors(req.EnabledCategories)
+	ctx = detector.WithSettings(ctx, detector.Settings{
+		Locale:          req.Locale,
+		ContextualDates: req.ContextualDates,
+		CorporateRanges: e.corporateRanges,
+		PhoneRegions:    e.phoneRegions,
+
+		NationalIDRegions: nationalIDRegions(req.EnabledCategories),
+	})
+
+	allFindings := make([]detector.Finding, 0)
+	var incomplete []string
+	for _, det := range enabledDetectors {
+		findings, ok := runDetector(ctx, det, text, req.Level)
+		if !ok && !contains(incomplete, string(det.Category())) {
+			incomplete = append(incomplete, string(det.Category()))
+		}
+		allFindings = append(allFindings, findings...)
+	}
+
+	// 按上下文关键词调整置信度和风险，去重时据此取舍重叠的匹配
+	detector.ScoreContext(text, allFindings)
+
+	// 应用白名单过滤
+	allFindings = e.applyAllowlist(allFindings, text, req.Allowlist)
+
+	// 去重（相同位置和类型）
+	return e.deduplicateFindings(allFindings), incomplete
+}
+
+// annotateFindings 转换为响应格式但不替换（标注模式）
+func (e *Engine) annotateFindings(findings []detector.Finding) []types.Finding {
+	convertedFindings := make([]types.Finding, len(findings))
+	for i, f := range findings {
+		convertedFindings[i] = types.Finding{
+			Type:               string(f.Type),
+			Start:              f.Start,
+			End:                f.End,
+			Confidence:         f.Confidence,
+			Risk:               f.Risk,
+			Replacement:        f.Text, // 标注模式下不替换
+			ReplacementPreview: e.getPreview(f.Text, f.Risk),
+			Reason:             f.Reason,
+			Valid:              f.Valid,
+			Network:            f.Network,
+			Precision:          f.Precision,
+			IPClass:            f.IPClass,
+			CountryCode:        f.CountryCode,
+			LineType:           f.LineType,
+			RuleID:             f.RuleID,
+			DocumentType:       f.DocumentType,
+			Region:             f.Region,
+		}
+	}
+	return convertedFindings
+}
+
+// AddDetector 注册额外的检测器（如 SDK 使用方提供的自定义检测器）
+// 只应在开始处理请求之前调用
+func (e *Engine) AddDetector(d detector.Detector) {
+	e.detectors = append(e.detectors, d)
+}
+
+// SetCorporateRanges 设置企业内网网段（CIDR 或单个地址），只应在开始处理请求之前调用
+func (e *Engine) SetCorporateRanges(ranges []string) error {
+	prefixes, err := detector.ParseIPRanges(ranges)
+	if err != nil {
+		return &types.RequestError{
+			Code:    types.ErrCodeInvalidIPRange,
+			Field:   "corporate_ranges",
+			Message: err.Error(),
+		}
+	}
+	e.corporateRanges = prefixes
+	return nil
+}
+
+// SetPhoneRegions 设置识别国内格式电话号码的地区，带国际前缀的号码不受影响
+// 只应在开始处理请求之前调用
+func (e *Engine) SetPhoneRegions(regions []string) error {
+	for _, r := range regions {
+		if !detector.ValidPhoneRegion(r) {
+			return &types.RequestError{
+				Code:    types.ErrCodeInvalidPhoneRegion,
+				Field:   "phone_regions",
+				Message: fmt.Sprintf("unsupported phone region %q: must be one of %s", r, strings.Join(detector.PhoneRegionNames(), ", ")),
+			}
+		}
+	}
+	e.phoneRegions = regions
+	return nil
+}
+
+// Categories 返回引擎支持的全部检测类别（按检测器注册顺序）
+func (e *Engine) Categories() []string {
+	categories := make([]string, 0, len(e.detectors))
+	seen := make(map[detector.Category]bool)
+	for _, det := range e.detectors {
+		if seen[det.Category()] {
+			continue
+		}
+		seen[det.Category()] = true
+		categories = append(categories, string(det.Category()))
+	}
+	return categories
+}
+
 // getEnabledDetectors 获取启用的检测器
 func (e *Engine) getEnabledDetectors(enabledCategories []string) []detector.Detector {
 	if len(enabledCategories) == 0 {
@@ -108,7 +239,8 @@ func (e *Engine) getEnabledDetectors(enabledCategories []string) []detector.Dete
 
 	categoryMap := make(map[string]bool)
 	for _, cat := range enabledCategories {
-		categoryMap[cat] = true
+		name, _, _ := strings.Cut(cat, ":")
+		categoryMap[name] = true
 	}
 
 	var enabled []detector.Detector
@@ -120,6 +252,23 @@ func (e *Engine) getEnabledDetectors(enabledCategories []string) []detector.Dete
 	return enabled
 }
 
+// nationalIDRegions 解析 enabled_categories 中的境外证件地区
+// national_id 启用全部地区，national_id:<地区> 只启用指定地区，都没有时返回 nil，由检测器按 locale 中的地区启用
+func nationalIDRegions(enabledCategories []string) []string {
+	var regions []string
+	for _, cat := range enabledCategories {
+		name, region, qualified := strings.Cut(cat, ":")
+		if name != string(detector.CategoryNationalID) {
+			continue
+		}
+		if !qualified {
+			return detector.NationalIDRegionNames()
+		}
+		regions = append(regions, strings.ToUpper(region))
+	}
+	return regions
+}
+
 // applyAllowlist 应用白名单
 func (e *Engine) applyAllowlist(findings []detector.Finding, text string, allowlist []string) []detector.Finding {
 	if len(allowlist) == 0 {
@@ -165,60 +314,69 @@ func (e *Engine) deduplicateFindings(findings []detector.Finding) []detector.Fin
 		detector.CategoryDatabaseConn:  10,
 		detector.CategoryName:          5,
 		detector.CategoryDate:          6,
+		detector.CategoryCompanyID:     7,
+		detector.CategoryLicensePlate:  6,
+		detector.CategoryNationalID:    9,
 	}
 
 	sorted := make([]detector.Finding, len(findings))
 	copy(sorted, findings)
-	
-	// 排序：风险高的优先，然后按类型优先级，最后按长度
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			shouldSwap := false
-			
-			// 优先按风险排序
-			if sorted[i].Risk < sorted[j].Risk {
-				shouldSwap = true
-			} else if sorted[i].Risk == sorted[j].Risk {
-				// 风险相同时，按类型优先级
-				priI := typePriority[sorted[i].Type]
-				priJ := typePriority[sorted[j].Type]
-				if priI < priJ {
-					shouldSwap = true
-				} else if priI == priJ {
-					// 类型优先级相同时，按长度（长的优先）
-					lenI := sorted[i].End - sorted[i].Start
-					lenJ := sorted[j].End - sorted[j].Start
-					if lenI < lenJ {
-						shouldSwap = true
-					}
-				}
-			}
-			
-			if shouldSwap {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			}
+
+	// 排序：风险高的优先，然后按类型优先级，最后按长度（长的优先）
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Risk != sorted[j].Risk {
+			return sorted[i].Risk > sorted[j].Risk
 		}
-	}
+		priI := typePriority[sorted[i].Type]
+		priJ := typePriority[sorted[j].Type]
+		if priI != priJ {
+			return priI > priJ
+		}
+		return sorted[i].End-sorted[i].Start > sorted[j].End-sorted[j].Start
+	})
 
 	// 去除重叠：如果两个 finding 重叠，保留优先级高的
-	var unique []detector.Finding
+	// 用位图记录已被保留的 finding 覆盖的字节，检查代价只与匹配长度有关
+	maxEnd := 0
 	for _, f := range sorted {
-		overlapped := false
-		for _, existing := range unique {
-			// 检查是否重叠：两个区间有交集
-			if !(f.End <= existing.Start || f.Start >= existing.End) {
-				overlapped = true
-				break
-			}
+		if f.End > maxEnd {
+			maxEnd = f.End
 		}
-		if !overlapped {
-			unique = append(unique, f)
+	}
+	covered := make(coverage, maxEnd/64+1)
+
+	var unique []detector.Finding
+	for _, f := range sorted {
+		if f.Start < 0 || f.Start >= f.End || covered.any(f.Start, f.End) {
+			continue
 		}
+		covered.mark(f.Start, f.End)
+		unique = append(unique, f)
 	}
 
 	return unique
 }
 
+// coverage 按字节记录文本中已被占用的区间
+type coverage []uint64
+
+// any 检查 [start, end) 中是否有已占用的字节
+func (c coverage) any(start, end int) bool {
+	for i := start; i < end; i++ {
+		if c[i/64]&(1<<(uint(i)%64)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mark 将 [start, end) 标记为已占用
+func (c coverage) mark(start, end int) {
+	for i := start; i < end; i++ {
+		c[i/64] |= 1 << (uint(i) % 64)
+	}
+}
+
 // calculateStats 计算统计信息
 func (e *Engine) calculateStats(findings []types.Finding) types.Stats {
 	stats := types.Stats{
@@ -226,36 +384,45 @@ func (e *Engine) calculateStats(findings []types.Finding) types.Stats {
 	}
 
 	for _, f := range findings {
-		stats.TotalFindings++
-		stats.ByCategory[f.Type]++
-
-		if f.Risk >= 70 {
-			stats.HighRiskCount++
-		} else if f.Risk >= 40 {
-			stats.MediumRiskCount++
-		} else {
-			stats.LowRiskCount++
-		}
+		addStats(&stats, f)
 	}
 
 	return stats
 }
 
-// calculateRiskScore 计算风险评分 (0-100)
-func (e *Engine) calculateRiskScore(findings []types.Finding) int {
-	if len(findings) == 0 {
-		return 0
+// addStats 将单个 finding 计入统计信息
+func addStats(stats *types.Stats, f types.Finding) {
+	stats.TotalFindings++
+	stats.ByCategory[f.Type]++
+
+	if f.Risk >= 70 {
+		stats.HighRiskCount++
+	} else if f.Risk >= 40 {
+		stats.MediumRiskCount++
+	} else {
+		stats.LowRiskCount++
 	}
+}
 
+// calculateRiskScore 计算风险评分 (0-100)
+func (e *Engine) calculateRiskScore(findings []types.Finding) int {
 	totalRisk := 0
 	for _, f := range findings {
 		totalRisk += f.Risk
 	}
+	return riskScore(totalRisk, len(findings))
+}
+
+// riskScore 根据风险总和与命中数量计算风险评分
+func riskScore(totalRisk, count int) int {
+	if count == 0 {
+		return 0
+	}
 
-	avgRisk := float64(totalRisk) / float64(len(findings))
+	avgRisk := float64(totalRisk) / float64(count)
 
 	// 考虑数量因素
-	countFactor := float64(len(findings)) * 2
+	countFactor := float64(count) * 2
 	if countFactor > 50 {
 		countFactor = 50
 	}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prompt-sanitizer/engine/internal/detector"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// 单次请求的默认资源预算
+const (
+	DefaultMaxInputSize = 16 << 20         // 文本最大字节数（16MB），更大的输入应使用流式处理
+	DefaultTimeout      = 30 * time.Second // 最长处理时间
+)
+
+// Limits 单次请求的资源预算，超出大小直接拒绝，超时则返回已完成检测器的部分结果
+// 流式处理不受这两个限制，只响应调用方的 ctx
+type Limits struct {
+	MaxInputSize int           // 文本最大字节数，0 表示不限制
+	Timeout      time.Duration // 最长处理时间，0 表示不限制
+}
+
+// SetLimits 设置资源预算，只应在开始处理请求之前调用
+func (e *Engine) SetLimits(limits Limits) {
+	e.limits = limits
+}
+
+// checkInputSize 拒绝超过大小预算的文本
+func (e *Engine) checkInputSize(text string) error {
+	if e.limits.MaxInputSize > 0 && len(text) > e.limits.MaxInputSize {
+		return &types.RequestError{
+			Code:    types.ErrCodeInputTooLarge,
+			Field:   "text",
+			Message: fmt.Sprintf("text is %d bytes, exceeds limit of %d bytes; use stream mode for large inputs", len(text), e.limits.MaxInputSize),
+		}
+	}
+	return nil
+}
+
+// withTimeout 按引擎预算和请求中的 timeout_ms 取较小者设置截止时间
+func (e *Engine) withTimeout(ctx context.Context, req *types.Request) (context.Context, context.CancelFunc) {
+	timeout := e.limits.Timeout
+	if reqTimeout := time.Duration(req.TimeoutMs) * time.Millisecond; reqTimeout > 0 && (timeout == 0 || reqTimeout < timeout) {
+		timeout = reqTimeout
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// detectResult 检测器在独立 goroutine 中的执行结果
+type detectResult struct {
+	findings []detector.Finding
+	panicked interface{}
+}
+
+// runDetector 执行一个检测器，ctx 结束后不再等待其返回
+// 返回的 bool 表示检测器是否完整执行
+// Go 无法强制结束 goroutine：超时后检测器仍在后台运行，直到它检查 ctx 并返回。
+// 内置检测器在匹配循环中检查 ctx.Err()，自定义检测器也必须这样做，否则超时只能让调用方先返回，
+// 病态输入仍会占用 CPU 和内存
+func runDetector(ctx context.Context, det detector.Detector, text, level string) ([]detector.Finding, bool) {
+	// 不会结束的 ctx 无需额外的 goroutine
+	if ctx.Done() == nil {
+		return det.Detect(ctx, text, level), true
+	}
+
+	done := make(chan detectResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- detectResult{panicked: r}
+			}
+		}()
+		done <- detectResult{findings: det.Detect(ctx, text, level)}
+	}()
+
+	select {
+	case res := <-done:
+		// 在调用方的 goroutine 中重新 panic，交给上层的 recover 处理
+		if res.panicked != nil {
+			panic(res.panicked)
+		}
+		// 检测器可能因 ctx 结束而提前返回
+		return res.findings, ctx.Err() == nil
+	case <-ctx.Done():
+		return nil, false
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// position 表示文本中的一个位置在不同计数方式下的值
+type position struct {
+	runeOff  int
+	utf16Off int
+	line     int
+	column   int
+}
+
+// positionTracker 顺序扫描文本，将字节偏移换算为字符偏移、UTF-16 偏移和行列号
+// 只能向前移动，流式处理时跨窗口复用以保持计数连续
+type positionTracker struct {
+	byteOff int
+	pos     position
+}
+
+func newPositionTracker() *positionTracker {
+	return &positionTracker{pos: position{line: 1, column: 1}}
+}
+
+// advance 扫描到字节偏移 target，text[0] 对应输入中的偏移 base
+func (t *positionTracker) advance(text string, base, target int) {
+	for t.byteOff < target {
+		r, size := utf8.DecodeRuneInString(text[t.byteOff-base:])
+		t.byteOff += size
+		t.pos.runeOff++
+		// 基本多文种平面之外的字符在 UTF-16 中占两个码元
+		if r >= 0x10000 && r <= utf8.MaxRune {
+			t.pos.utf16Off += 2
+		} else {
+			t.pos.utf16Off++
+		}
+		if r == '\n' {
+			t.pos.line++
+			t.pos.column = 1
+		} else {
+			t.pos.column++
+		}
+	}
+}
+
+// fill 为 findings 填充字符偏移、UTF-16 偏移和行列号
+// text[0] 对应输入中的偏移 base，findings 的偏移是相对整个输入的
+func (t *positionTracker) fill(text string, base int, findings []types.Finding) {
+	offsets := make([]int, 0, 2*len(findings))
+	for _, f := range findings {
+		offsets = append(offsets, f.Start, f.End)
+	}
+	sort.Ints(offsets)
+
+	positions := make(map[int]position, len(offsets))
+	for _, off := range offsets {
+		if off < t.byteOff || off > base+len(text) {
+			continue
+		}
+		t.advance(text, base, off)
+		positions[off] = t.pos
+	}
+
+	for i := range findings {
+		start := positions[findings[i].Start]
+		end := positions[findings[i].End]
+		findings[i].RuneStart = start.runeOff
+		findings[i].RuneEnd = end.runeOff
+		findings[i].UTF16Start = start.utf16Off
+		findings[i].UTF16End = end.utf16Off
+		findings[i].Line = start.line
+		findings[i].Column = start.column
+		findings[i].EndLine = end.line
+		findings[i].EndColumn = end.column
+	}
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prompt-sanitizer/engine/internal/config"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// UseConfig 加载配置文件中的命名配置、资源预算、企业内网网段和电话号码地区，每个配置都会按请求的规则校验
+func (e *Engine) UseConfig(cfg *config.Config) error {
+	for name, profile := range cfg.Profiles {
+		req := types.Request{
+			Mode:              profile.Mode,
+			Strategy:          profile.Strategy,
+			Level:             profile.Level,
+			EnabledCategories: profile.EnabledCategories,
+			Locale:            profile.Locale,
+		}
+		if _, err := e.Validate(&req); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+
+	if err := e.SetCorporateRanges(cfg.CorporateRanges); err != nil {
+		return err
+	}
+	if err := e.SetPhoneRegions(cfg.PhoneRegions); err != nil {
+		return err
+	}
+
+	e.profiles = cfg.Profiles
+	e.defaultProfile = cfg.DefaultProfile
+	if cfg.Limits.MaxInputSize > 0 {
+		e.limits.MaxInputSize = cfg.Limits.MaxInputSize
+	}
+	if cfg.Limits.TimeoutMs > 0 {
+		e.limits.Timeout = time.Duration(cfg.Limits.TimeoutMs) * time.Millisecond
+	}
+	return nil
+}
+
+// Profiles 返回已加载的配置名称（按名称排序）
+func (e *Engine) Profiles() []string {
+	names := make([]string, 0, len(e.profiles))
+	for name := range e.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve 合并命名配置、填充默认值并校验，返回实际用于处理的请求副本
+// 请求中填写的字段覆盖配置中的值；数组字段省略时继承配置，显式传入空数组表示清空
+func (e *Engine) Resolve(req *types.Request) (*types.Request, []types.Warning, error) {
+	resolved := *req
+
+	name := req.Profile
+	if name == "" {
+		name = e.defaultProfile
+	}
+	if name != "" {
+		profile, ok := e.profiles[name]
+		if !ok {
+			return nil, nil, &types.RequestError{
+				Code:    types.ErrCodeUnknownProfile,
+				Field:   "profile",
+				Message: fmt.Sprintf("unknown profile %q: must be one of %s", name, strings.Join(e.Profiles(), ", ")),
+			}
+		}
+		if resolved.Mode == "" {
+			resolved.Mode = profile.Mode
+		}
+		if resolved.Strategy == "" {
+			resolved.Strategy = profile.Strategy
+		}
+		if resolved.Level == "" {
+			resolved.Level = profile.Level
+		}
+		if resolved.EnabledCategories == nil {
+			resolved.EnabledCategories = profile.EnabledCategories
+		}
+		if resolved.Allowlist == nil {
+			resolved.Allowlist = profile.Allowlist
+		}
+		if resolved.Locale == "" {
+			resolved.Locale = profile.Locale
+		}
+		// 布尔开关无法区分省略和 false，任一方开启即开启
+		resolved.ContextualDates = resolved.ContextualDates || profile.ContextualDates
+	}
+
+	// 设置默认值
+	if resolved.Mode == "" {
+		resolved.Mode = "sanitize"
+	}
+	if resolved.Strategy == "" {
+		resolved.Strategy = "redact"
+	}
+	if resolved.Level == "" {
+		resolved.Level = "standard"
+	}
+	if resolved.SemanticMode == "" {
+		resolved.SemanticMode = "off"
+	}
+
+	warnings, err := e.Validate(&resolved)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resolved, warnings, nil
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"unicode/utf8"
+
+	"github.com/prompt-sanitizer/engine/internal/detector"
+	"github.com/prompt-sanitizer/engine/internal/sanitizer"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// 流式处理的默认参数
+const (
+	DefaultChunkSize = 1 << 20  // 每个窗口新读入的字节数（1MB）
+	DefaultOverlap   = 64 << 10 // 相邻窗口的重叠字节数（64KB）
+
+	// streamLeftContext 每个窗口保留的已输出文本，用于匹配 "password=" 这类依赖前文的规则
+	streamLeftContext = 1024
+)
+
+// StreamOptions 流式处理参数
+type StreamOptions struct {
+	ChunkSize int // 每个窗口新读入的字节数，默认 DefaultChunkSize
+	Overlap   int // 相邻窗口的重叠字节数，也是跨边界匹配的最大长度，默认 DefaultOverlap
+
+	// OnFinding 设置后每个 finding 通过回调返回，不在响应中累积，
+	// 处理超大输入时可以保持内存占用恒定
+	OnFinding func(types.Finding)
+}
+
+// ProcessStream 以重叠窗口的方式流式处理 r，将清洗后的文本写入 w
+//
+// 每个窗口只输出前 ChunkSize 字节，剩余的 Overlap 字节与下一个窗口重叠，
+// 因此长度不超过 Overlap 的匹配（PEM 私钥、JWT 等）即使跨越块边界也能被识别。
+// 返回的响应中 SanitizedText 为空，finding 的偏移量是相对整个输入的字节偏移。
+// 流式处理不受 Limits 限制，ctx 结束时返回其错误，此时 w 中只有部分输出。
+func (e *Engine) ProcessStream(ctx context.Context, req *types.Request, r io.Reader, w io.Writer, opts StreamOptions) (*types.Response, error) {
+	req, warnings, err := e.Resolve(req)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	overlap := opts.Overlap
+	if overlap <= 0 {
+		overlap = DefaultOverlap
+	}
+
+	var san *sanitizer.Sanitizer
+	if req.Mode != "annotate" {
+		// 整个流共用一个清洗器，保证一致化替换的代号不变
+		san = sanitizer.NewSanitizer(req.Strategy, nil)
+	}
+
+	stats := types.Stats{ByCategory: make(map[string]int)}
+	totalRisk := 0
+	var collected []types.Finding
+	tracker := newPositionTracker()
+
+	buf := make([]byte, 0, streamLeftContext+chunkSize+overlap)
+	bufBase := 0   // buf[0] 在输入中的偏移
+	committed := 0 // 已输出到 w 的输入偏移
+	eof := false
+
+	for {
+		// 读满一个窗口：已输出位置之后至少有 chunkSize+overlap 字节
+		for !eof && len(buf)-(committed-bufBase) < chunkSize+overlap {
+			if len(buf) == cap(buf) {
+				grown := make([]byte, len(buf), 2*cap(buf))
+				copy(grown, buf)
+				buf = grown
+			}
+			n, err := r.Read(buf[len(buf):cap(buf)])
+			buf = buf[:len(buf)+n]
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		window := string(buf)
+		start := committed - bufBase
+
+		// 只提交起点在 [start, cut) 内的匹配，cut 之后的部分留给下一个窗口
+		cut := len(window)
+		if !eof {
+			cut = runeStartBefore(window, len(window)-overlap)
+			if cut <= start {
+				cut = len(window)
+			}
+		}
+
+		var accepted []detector.Finding
+		boundary := cut
+		findings, _ := e.detect(ctx, req, window)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, f := range findings {
+			if f.Start < start || f.Start >= cut {
+				continue
+			}
+			// 转换为相对本段输出的偏移
+			f.Start -= start
+			f.End -= start
+			accepted = append(accepted, f)
+			if f.End+start > boundary {
+				boundary = f.End + start
+			}
+		}
+
+		original := window[start:boundary]
+		segment := original
+		var converted []types.Finding
+		if san != nil {
+			segment, converted = san.SanitizeFindings(segment, accepted)
+		} else {
+			converted = e.annotateFindings(accepted)
+		}
+		if _, err := io.WriteString(w, segment); err != nil {
+			return nil, err
+		}
+
+		for i := range converted {
+			converted[i].Start += committed
+			converted[i].End += committed
+		}
+		tracker.fill(original, committed, converted)
+		tracker.advance(original, committed, committed+len(original))
+
+		for _, f := range converted {
+			addStats(&stats, f)
+			totalRisk += f.Risk
+			if opts.OnFinding != nil {
+				opts.OnFinding(f)
+			} else {
+				collected = append(collected, f)
+			}
+		}
+
+		committed = bufBase + boundary
+		if eof && boundary == len(window) {
+			break
+		}
+
+		// 丢弃已输出的文本，只保留少量前文
+		keepFrom := 0
+		if boundary > streamLeftContext {
+			keepFrom = runeStartBefore(window, boundary-streamLeftContext)
+		}
+		buf = buf[:copy(buf, buf[keepFrom:])]
+		bufBase += keepFrom
+	}
+
+	if collected == nil {
+		collected = make([]types.Finding, 0)
+	}
+	return &types.Response{
+		Findings:  collected,
+		Stats:     stats,
+		RiskScore: riskScore(totalRisk, stats.TotalFindings),
+		Version:   Version,
+		Warnings:  warnings,
+	}, nil
+}
+
+// runeStartBefore 返回不大于 i 的最近一个 UTF-8 字符起始位置
+func runeStartBefore(s string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prompt-sanitizer/engine/internal/detector"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// 请求中各枚举字段支持的取值，空值表示使用默认值
+var (
+	validModes         = []string{"annotate", "sanitize"}
+	validStrategies    = []string{"mask", "pseudonym", "redact"}
+	validLevels        = []string{"lenient", "standard", "strict"}
+	validSemanticModes = []string{"off", "on"}
+
+	// localePattern BCP 47 语言标签的基本形式，如 zh、en-US、zh-Hans-CN
+	localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(?:[-_][A-Za-z0-9]{2,8})*$`)
+)
+
+// Validate 校验请求中的枚举值和类别
+// 不支持的取值返回 *types.RequestError，不影响处理的问题作为警告返回
+func (e *Engine) Validate(req *types.Request) ([]types.Warning, error) {
+	enums := []struct {
+		field string
+		value string
+		valid []string
+		code  string
+	}{
+		{"mode", req.Mode, validModes, types.ErrCodeInvalidMode},
+		{"strategy", req.Strategy, validStrategies, types.ErrCodeInvalidStrategy},
+		{"level", req.Level, validLevels, types.ErrCodeInvalidLevel},
+		{"semantic_mode", req.SemanticMode, validSemanticModes, types.ErrCodeInvalidSemanticMode},
+	}
+	for _, enum := range enums {
+		if enum.value != "" && !contains(enum.valid, enum.value) {
+			return nil, &types.RequestError{
+				Code:    enum.code,
+				Field:   enum.field,
+				Message: fmt.Sprintf("invalid %s %q: must be one of %s", enum.field, enum.value, strings.Join(enum.valid, ", ")),
+			}
+		}
+	}
+
+	if req.TimeoutMs < 0 {
+		return nil, &types.RequestError{
+			Code:    types.ErrCodeInvalidTimeout,
+			Field:   "timeout_ms",
+			Message: fmt.Sprintf("invalid timeout_ms %d: must not be negative", req.TimeoutMs),
+		}
+	}
+
+	if req.Locale != "" && !localePattern.MatchString(req.Locale) {
+		return nil, &types.RequestError{
+			Code:    types.ErrCodeInvalidLocale,
+			Field:   "locale",
+			Message: fmt.Sprintf("invalid locale %q: must be a BCP 47 language tag such as en-US", req.Locale),
+		}
+	}
+
+	var warnings []types.Warning
+
+	known := e.Categories()
+	seen := make(map[string]bool)
+	for _, cat := range req.EnabledCategories {
+		name, region, qualified := strings.Cut(cat, ":")
+		if qualified && name == string(detector.CategoryNationalID) && contains(known, name) {
+			if !detector.ValidNationalIDRegion(region) {
+				return nil, &types.RequestError{
+					Code:    types.ErrCodeUnknownCategory,
+					Field:   "enabled_categories",
+					Message: fmt.Sprintf("unknown national_id region %q: must be one of %s", region, strings.Join(detector.NationalIDRegionNames(), ", ")),
+				}
+			}
+			cat = name + ":" + strings.ToUpper(region)
+		} else if !contains(known, cat) {
+			sorted := append([]string(nil), known...)
+			sort.Strings(sorted)
+			return nil, &types.RequestError{
+				Code:    types.ErrCodeUnknownCategory,
+				Field:   "enabled_categories",
+				Message: fmt.Sprintf("unknown category %q: must be one of %s", cat, strings.Join(sorted, ", ")),
+			}
+		}
+		if seen[cat] {
+			warnings = append(warnings, types.Warning{
+				Code:    types.WarnCodeDuplicateCategory,
+				Field:   "enabled_categories",
+				Message: fmt.Sprintf("category %q is listed more than once", cat),
+			})
+		}
+		seen[cat] = true
+	}
+
+	// semantic_mode 一直是预留字段，开启后不会产生任何效果
+	if req.SemanticMode == "on" {
+		warnings = append(warnings, types.Warning{
+			Code:    types.WarnCodeDeprecatedField,
+			Field:   "semantic_mode",
+			Message: "semantic_mode is deprecated and has no effect; requests are processed as if it were \"off\"",
+		})
+	}
+
+	return warnings, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
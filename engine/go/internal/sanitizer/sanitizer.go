@@ -26,25 +26,33 @@ func NewSanitizer(strategy string, findings []detector.Finding) *Sanitizer {
 
 // Sanitize 执行清洗
 func (s *Sanitizer) Sanitize(text string) (string, []types.Finding) {
-	// 按位置排序，从后往前替换避免索引偏移
-	sortedFindings := make([]detector.Finding, len(s.findings))
-	copy(sortedFindings, s.findings)
+	return s.SanitizeFindings(text, s.findings)
+}
+
+// SanitizeFindings 将 findings 应用到 text，返回清洗后的文本和转换后的 findings
+// 可以多次调用，一致化替换的代号在同一个 Sanitizer 内保持不变
+func (s *Sanitizer) SanitizeFindings(text string, findings []detector.Finding) (string, []types.Finding) {
+	// 按位置排序后一次性拼接，避免反复切片拼接字符串
+	sortedFindings := make([]detector.Finding, len(findings))
+	copy(sortedFindings, findings)
 	sort.Slice(sortedFindings, func(i, j int) bool {
-		return sortedFindings[i].Start > sortedFindings[j].Start
+		return sortedFindings[i].Start < sortedFindings[j].Start
 	})
 
-	result := text
+	var result strings.Builder
+	result.Grow(len(text))
 	convertedFindings := make([]types.Finding, 0, len(sortedFindings))
 
+	// 使用字节索引，因为 Start 和 End 是基于字节的
+	cursor := 0
 	for _, f := range sortedFindings {
 		replacement := s.getReplacement(f)
 		preview := s.getPreview(f, replacement)
 
-		// 替换文本（使用字节索引，因为 Start 和 End 是基于字节的）
-		start := f.Start
-		end := f.End
-		if start >= 0 && end <= len(result) && start < end {
-			result = result[:start] + replacement + result[end:]
+		if f.Start >= cursor && f.End <= len(text) && f.Start < f.End {
+			result.WriteString(text[cursor:f.Start])
+			result.WriteString(replacement)
+			cursor = f.End
 		}
 
 		convertedFindings = append(convertedFindings, types.Finding{
@@ -56,21 +64,33 @@ func (s *Sanitizer) Sanitize(text string) (string, []types.Finding) {
 			Replacement:        replacement,
 			ReplacementPreview: preview,
 			Reason:             f.Reason,
+			Valid:              f.Valid,
+			Network:            f.Network,
+			Precision:          f.Precision,
+			IPClass:            f.IPClass,
+			CountryCode:        f.CountryCode,
+			LineType:           f.LineType,
+			RuleID:             f.RuleID,
+			DocumentType:       f.DocumentType,
+			Region:             f.Region,
 		})
 	}
+	result.WriteString(text[cursor:])
 
-	// 反转顺序以匹配原始顺序
-	for i, j := 0, len(convertedFindings)-1; i < j; i, j = i+1, j-1 {
-		convertedFindings[i], convertedFindings[j] = convertedFindings[j], convertedFindings[i]
-	}
-
-	return result, convertedFindings
+	return result.String(), convertedFindings
 }
 
 // getReplacement 获取替换文本
 func (s *Sanitizer) getReplacement(f detector.Finding) string {
 	switch s.strategy {
 	case "mask":
+		if f.Type == detector.CategoryPhone {
+			// 电话号码需要国家代码才能确定保留的前缀
+			return maskPhone(f.Text, f.CountryCode)
+		}
+		if f.Type == detector.CategoryToken {
+			return s.maskToken(f.Text, f.RuleID)
+		}
 		return s.mask(f.Text, f.Type)
 	case "redact":
 		return s.redact(f.Type)
@@ -92,8 +112,7 @@ func (s *Sanitizer) mask(text string, category detector.Category) string {
 	var prefixLen, suffixLen int
 	switch category {
 	case detector.CategoryPhone:
-		// 手机号：保留前3位和后4位，如 138****0000
-		prefixLen, suffixLen = 3, 4
+		return maskPhone(text, "")
 	case detector.CategoryEmail:
 		// 邮箱：保留@前3位和@后完整域名，如 san***@example.com
 		atIndex := strings.Index(text, "@")
@@ -123,6 +142,15 @@ func (s *Sanitizer) mask(text string, category detector.Category) string {
 	case detector.CategoryDriverLicense:
 		// 驾照号：保留前2位和后3位，如 BJ*****001
 		prefixLen, suffixLen = 2, 3
+	case detector.CategoryCompanyID:
+		// 企业证照号码：保留登记管理部门、机构类别和后4位，如 91************821H
+		prefixLen, suffixLen = 2, 4
+	case detector.CategoryLicensePlate:
+		// 车牌号：保留省份简称、发牌机关代号和后2位，如 京A***45
+		return maskPlate(text)
+	case detector.CategoryNationalID:
+		// 境外证件号码：只保留后4位，如 *******6789
+		prefixLen, suffixLen = 0, 4
 	case detector.CategoryAddress:
 		// 地址：保留省市区，打码详细地址，如 北京市朝阳区****
 		// 简化处理：保留前6个字符，其余打码
@@ -173,7 +201,7 @@ func (s *Sanitizer) mask(text string, category detector.Category) string {
 		prefixLen, suffixLen = 4, 0
 	case detector.CategoryToken:
 		// Token/密钥：根据不同类型采用不同脱敏策略
-		return s.maskToken(text)
+		return s.maskToken(text, "")
 	case detector.CategoryPassword:
 		// 密码：全部打码，如 ********
 		return strings.Repeat("*", length)
@@ -197,13 +225,82 @@ func (s *Sanitizer) mask(text string, category detector.Category) string {
 	return prefix + masked + suffix
 }
 
-// maskToken 针对不同类型的Token/密钥采用精细脱敏策略
-func (s *Sanitizer) maskToken(text string) string {
+// maskPhone 电话号码：保留国际前缀、国家代码和后4位，分隔符不变，如 +44 ** **** 0958
+// 国内格式的11位号码保留前3位和后4位，如 138****0000
+func maskPhone(text, countryCode string) string {
+	digits := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] >= '0' && text[i] <= '9' {
+			digits++
+		}
+	}
+
+	keepHead := 0
+	switch {
+	case strings.HasPrefix(text, "+") && countryCode != "":
+		keepHead = len(countryCode)
+	case strings.HasPrefix(text, "00") && countryCode != "":
+		keepHead = 2 + len(countryCode)
+	case digits >= 11:
+		keepHead = 3
+	}
+	keepTail := 4
+	if keepHead+keepTail >= digits {
+		return "****"
+	}
+
+	masked := []byte(text)
+	seen := 0
+	for i := range masked {
+		if masked[i] < '0' || masked[i] > '9' {
+			continue
+		}
+		if seen >= keepHead && seen < digits-keepTail {
+			masked[i] = '*'
+		}
+		seen++
+	}
+	return string(masked)
+}
+
+// maskPlate 车牌号按字符打码并去掉分隔符（与检测器的 plateSeparator 相同），使馆号牌只保留"使"字
+func maskPlate(text string) string {
+	var runes []rune
+	for _, r := range text {
+		if !strings.ContainsRune("·•・ \t-", r) {
+			runes = append(runes, r)
+		}
+	}
+	keepHead := 2
+	if runes[0] == '使' {
+		keepHead = 1
+	}
+	if keepHead+2 >= len(runes) {
+		return "****"
+	}
+	return string(runes[:keepHead]) + strings.Repeat("*", len(runes)-keepHead-2) + string(runes[len(runes)-2:])
+}
+
+// maskToken 针对不同类型的Token/密钥采用精细脱敏策略，ruleID 为检测器命中的规则
+func (s *Sanitizer) maskToken(text, ruleID string) string {
 	length := len(text)
 	if length <= 4 {
 		return "****"
 	}
 
+	// 服务账号 JSON 中的私钥：全部替换为占位符
+	if strings.HasPrefix(text, "-----BEGIN") {
+		return "[PRIVATE_KEY_REDACTED]"
+	}
+
+	// 规则包中带固定前缀的厂商密钥：保留前缀和后4位，如 xoxb-****abcd、glpat-****wxyz
+	if prefix := detector.TokenMaskPrefix(ruleID, text); prefix != "" {
+		if length > len(prefix)+8 {
+			return prefix + strings.Repeat("*", length-len(prefix)-4) + text[length-4:]
+		}
+		return prefix + strings.Repeat("*", length-len(prefix))
+	}
+
 	textUpper := strings.ToUpper(text)
 	
 	// AWS Access Key ID: AKIA开头，固定20字符
@@ -299,10 +396,17 @@ func (s *Sanitizer) redact(category detector.Category) string {
 		detector.CategoryDatabaseConn:  "[REDACTED:DATABASE_CONN]",
 		detector.CategoryName:          "[REDACTED:NAME]",
 		detector.CategoryDate:          "[REDACTED:DATE]",
+		detector.CategoryCompanyID:     "[REDACTED:COMPANY_ID]",
+		detector.CategoryLicensePlate:  "[REDACTED:LICENSE_PLATE]",
+		detector.CategoryNationalID:    "[REDACTED:NATIONAL_ID]",
 	}
 	if name, ok := categoryMap[category]; ok {
 		return name
 	}
+	// 自定义类别使用类别名作为标签
+	if category != "" {
+		return "[REDACTED:" + strings.ToUpper(string(category)) + "]"
+	}
 	return "[REDACTED]"
 }
 
@@ -334,6 +438,9 @@ func (s *Sanitizer) pseudonym(text string, category detector.Category) string {
 		detector.CategoryDatabaseConn:  "DATABASE_CONN",
 		detector.CategoryName:          "NAME",
 		detector.CategoryDate:          "DATE",
+		detector.CategoryCompanyID:     "COMPANY_ID",
+		detector.CategoryLicensePlate:  "LICENSE_PLATE",
+		detector.CategoryNationalID:    "NATIONAL_ID",
 	}
 
 	prefix := categoryMap[category]
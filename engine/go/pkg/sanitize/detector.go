@@ -0,0 +1,57 @@
+package sanitize
+
+import (
+	"context"
+
+	"github.com/prompt-sanitizer/engine/internal/detector"
+)
+
+// Detector 自定义检测器
+//
+// Detect 可能被多个 goroutine 同时调用，实现需要保证并发安全。
+type Detector interface {
+	// Category 返回检测类别，如 "employee_id"，可以与内置类别相同
+	Category() string
+	// Detect 返回 text 中的所有匹配，ctx 结束后应尽快返回已找到的匹配；
+	// 超时后引擎不再等待，但不会检查 ctx 的实现会一直在后台运行
+	Detect(ctx context.Context, text string, level Level) []Match
+}
+
+// Match 表示自定义检测器的一个匹配
+type Match struct {
+	Start      int     // 起始位置（UTF-8 字节偏移）
+	End        int     // 结束位置（UTF-8 字节偏移）
+	Confidence float64 // 置信度 0-1
+	Risk       int     // 风险等级 0-100
+	Reason     string  // 识别原因说明
+}
+
+// detectorAdapter 将自定义检测器适配为引擎内部的检测器接口
+type detectorAdapter struct {
+	d Detector
+}
+
+func (a detectorAdapter) Category() detector.Category {
+	return detector.Category(a.d.Category())
+}
+
+func (a detectorAdapter) Detect(ctx context.Context, text string, level string) []detector.Finding {
+	matches := a.d.Detect(ctx, text, Level(level))
+	findings := make([]detector.Finding, 0, len(matches))
+	for _, m := range matches {
+		// 忽略越界或为空的匹配
+		if m.Start < 0 || m.End > len(text) || m.Start >= m.End {
+			continue
+		}
+		findings = append(findings, detector.Finding{
+			Type:       a.Category(),
+			Start:      m.Start,
+			End:        m.End,
+			Text:       text[m.Start:m.End],
+			Confidence: m.Confidence,
+			Risk:       m.Risk,
+			Reason:     m.Reason,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,24 @@
+// Package sanitize 是 Prompt Sanitizer 引擎的公开 Go SDK，供其他 Go 服务直接嵌入，
+// 不再需要通过子进程调用命令行程序。
+//
+//	s, err := sanitize.New(
+//		sanitize.WithStrategy(sanitize.StrategyMask),
+//		sanitize.WithCategories("phone", "email"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := s.Sanitize(ctx, "我的手机号是13812345678")
+//
+// # 兼容性保证
+//
+// 本包和 pkg/types 遵循语义化版本：
+//
+//   - 同一主版本内，已导出的类型、函数、选项和常量不会被删除，签名不会以不兼容的方式修改
+//   - 可能新增选项、方法、常量以及 Result/Finding 中的字段，使用方应使用带字段名的结构体字面量
+//   - 错误码（types.ErrCode*）和警告码（types.WarnCode*）的取值保持不变
+//   - 检测结果本身不在保证范围内：新版本可能识别更多内容，或调整置信度、风险值和 reason 文案
+//   - internal/ 下的包没有任何兼容性保证，请勿依赖
+//
+// Sanitizer 创建后不可修改，可以被多个 goroutine 同时使用。
+package sanitize
@@ -0,0 +1,208 @@
+package sanitize
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/prompt-sanitizer/engine/internal/engine"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// Version 引擎版本号
+const Version = engine.Version
+
+// Result 表示一次处理的结果
+type Result = types.Response
+
+// Finding 表示一个识别到的敏感信息
+type Finding = types.Finding
+
+// Strategy 清洗策略
+type Strategy string
+
+const (
+	StrategyMask      Strategy = "mask"      // 部分打码，保留前后缀
+	StrategyRedact    Strategy = "redact"    // 替换为 [REDACTED:TYPE] 占位符
+	StrategyPseudonym Strategy = "pseudonym" // 一致化替换，同一次调用中同一实体使用相同代号
+)
+
+// Level 清洗强度
+type Level string
+
+const (
+	LevelLenient  Level = "lenient"  // 只识别明显高风险内容
+	LevelStandard Level = "standard" // 平衡误报和漏报
+	LevelStrict   Level = "strict"   // 尽可能识别所有可能的敏感信息
+)
+
+// Option 创建 Sanitizer 时的配置项
+type Option func(*options)
+
+type options struct {
+	strategy   Strategy
+	level      Level
+	categories []string
+	allowlist  []string
+	detectors  []Detector
+	limits     engine.Limits
+
+	locale          string
+	contextualDates bool
+	corporateRanges []string
+	phoneRegions    []string
+}
+
+// WithStrategy 设置清洗策略，默认 StrategyRedact
+func WithStrategy(strategy Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// WithLevel 设置清洗强度，默认 LevelStandard
+func WithLevel(level Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithCategories 只启用指定的检测类别，默认启用全部（包括自定义检测器的类别）
+func WithCategories(categories ...string) Option {
+	return func(o *options) {
+		o.categories = append(o.categories, categories...)
+	}
+}
+
+// WithAllowlist 添加白名单字符串（精确匹配），命中的内容不会被识别
+func WithAllowlist(items ...string) Option {
+	return func(o *options) {
+		o.allowlist = append(o.allowlist, items...)
+	}
+}
+
+// WithLocale 设置区域设置（BCP 47，如 en-US、en-GB），用于区分日期中的月和日
+func WithLocale(locale string) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// WithContextualDates 识别姓名、证件号等身份信息附近未带关键词的日期
+func WithContextualDates() Option {
+	return func(o *options) {
+		o.contextualDates = true
+	}
+}
+
+// WithCorporateRanges 设置企业内网网段（CIDR 或单个地址，如 10.20.0.0/16），命中的 IP 地址标记为 corporate 并提高风险
+// 格式错误时 New 返回错误码为 INVALID_IP_RANGE 的 *types.RequestError
+func WithCorporateRanges(ranges ...string) Option {
+	return func(o *options) {
+		o.corporateRanges = append(o.corporateRanges, ranges...)
+	}
+}
+
+// WithPhoneRegions 设置识别国内格式电话号码的地区（ISO 3166-1，如 CN、HK、GB），默认只识别中国大陆号码
+// 带 + 或 00 国际前缀的号码总是按国家代码识别；不支持的地区使 New 返回错误码为 INVALID_PHONE_REGION 的 *types.RequestError
+func WithPhoneRegions(regions ...string) Option {
+	return func(o *options) {
+		o.phoneRegions = append(o.phoneRegions, regions...)
+	}
+}
+
+// WithDetector 注册自定义检测器，其类别可以在 WithCategories 中使用
+func WithDetector(d Detector) Option {
+	return func(o *options) {
+		o.detectors = append(o.detectors, d)
+	}
+}
+
+// WithTimeout 设置单次处理的时间预算，默认 30 秒，0 表示不限制
+// 超时后返回已完成检测器的部分结果，Result.Partial 为 true
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.limits.Timeout = timeout
+	}
+}
+
+// WithMaxInputSize 设置单次处理的文本最大字节数，默认 16MB，0 表示不限制
+// 超出时返回错误码为 INPUT_TOO_LARGE 的 *types.RequestError，更大的输入应使用 SanitizeStream
+func WithMaxInputSize(size int) Option {
+	return func(o *options) {
+		o.limits.MaxInputSize = size
+	}
+}
+
+// Sanitizer 敏感信息清洗器
+type Sanitizer struct {
+	eng      *engine.Engine
+	template types.Request
+}
+
+// New 创建清洗器，配置项中有不支持的取值时返回 *types.RequestError
+func New(opts ...Option) (*Sanitizer, error) {
+	o := options{
+		limits: engine.Limits{MaxInputSize: engine.DefaultMaxInputSize, Timeout: engine.DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	eng := engine.NewEngine()
+	eng.SetLimits(o.limits)
+	if err := eng.SetCorporateRanges(o.corporateRanges); err != nil {
+		return nil, err
+	}
+	if err := eng.SetPhoneRegions(o.phoneRegions); err != nil {
+		return nil, err
+	}
+	for _, d := range o.detectors {
+		eng.AddDetector(detectorAdapter{d})
+	}
+
+	template := types.Request{
+		Strategy:          string(o.strategy),
+		Level:             string(o.level),
+		EnabledCategories: o.categories,
+		Allowlist:         o.allowlist,
+		Locale:            o.locale,
+		ContextualDates:   o.contextualDates,
+	}
+	resolved, _, err := eng.Resolve(&template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sanitizer{eng: eng, template: *resolved}, nil
+}
+
+// Sanitize 识别并替换 text 中的敏感信息
+// ctx 被取消时返回 ctx 的错误；超过截止时间则返回部分结果，Result.Partial 为 true
+func (s *Sanitizer) Sanitize(ctx context.Context, text string) (*Result, error) {
+	return s.process(ctx, "sanitize", text)
+}
+
+// Annotate 只识别 text 中的敏感信息，不做替换
+func (s *Sanitizer) Annotate(ctx context.Context, text string) (*Result, error) {
+	return s.process(ctx, "annotate", text)
+}
+
+// SanitizeStream 流式清洗 r 中的内容并写入 w，内存占用与输入大小无关
+// 返回结果中 SanitizedText 为空，Finding 的偏移量相对整个输入
+// 流式处理不受 WithTimeout 和 WithMaxInputSize 限制，ctx 结束时返回其错误
+func (s *Sanitizer) SanitizeStream(ctx context.Context, r io.Reader, w io.Writer) (*Result, error) {
+	req := s.template
+	req.Mode = "sanitize"
+	return s.eng.ProcessStream(ctx, &req, r, w, engine.StreamOptions{})
+}
+
+func (s *Sanitizer) process(ctx context.Context, mode, text string) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	req := s.template
+	req.Mode = mode
+	req.Text = text
+	return s.eng.Process(ctx, &req)
+}
@@ -0,0 +1,157 @@
+package sanitize_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prompt-sanitizer/engine/pkg/sanitize"
+	"github.com/prompt-sanitizer/engine/pkg/types"
+)
+
+// employeeIDDetector 识别形如 EMP-123456 的工号
+type employeeIDDetector struct{}
+
+var employeeIDPattern = regexp.MustCompile(`EMP-\d{6}`)
+
+func (employeeIDDetector) Category() string { return "employee_id" }
+
+func (employeeIDDetector) Detect(ctx context.Context, text string, level sanitize.Level) []sanitize.Match {
+	var matches []sanitize.Match
+	for _, loc := range employeeIDPattern.FindAllStringIndex(text, -1) {
+		matches = append(matches, sanitize.Match{Start: loc[0], End: loc[1], Confidence: 0.9, Risk: 60, Reason: "工号"})
+	}
+	return matches
+}
+
+func TestSanitize(t *testing.T) {
+	s, err := sanitize.New(sanitize.WithCategories("phone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "电话13812345678，邮箱test@example.com"
+	result, err := s.Sanitize(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SanitizedText != "电话[REDACTED:PHONE]，邮箱test@example.com" {
+		t.Errorf("unexpected sanitized text: %s", result.SanitizedText)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].Type != "phone" {
+		t.Errorf("unexpected findings: %+v", result.Findings)
+	}
+
+	result, err = s.Annotate(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SanitizedText != text || len(result.Findings) != 1 {
+		t.Errorf("annotate should keep text: %+v", result)
+	}
+}
+
+func TestWithPhoneRegions(t *testing.T) {
+	s, err := sanitize.New(sanitize.WithCategories("phone"), sanitize.WithPhoneRegions("GB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Annotate(context.Background(), "英国 020 7946 0958，国内 13812345678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].CountryCode != "44" {
+		t.Errorf("expected only the GB number, got %+v", result.Findings)
+	}
+}
+
+func TestCustomDetector(t *testing.T) {
+	s, err := sanitize.New(
+		sanitize.WithDetector(employeeIDDetector{}),
+		sanitize.WithCategories("employee_id"),
+		sanitize.WithAllowlist("EMP-000000"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Sanitize(context.Background(), "工号EMP-123456，测试账号EMP-000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SanitizedText != "工号[REDACTED:EMPLOYEE_ID]，测试账号EMP-000000" {
+		t.Errorf("unexpected sanitized text: %s", result.SanitizedText)
+	}
+}
+
+func TestSanitizeStream(t *testing.T) {
+	s, err := sanitize.New(sanitize.WithStrategy(sanitize.StrategyMask))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	result, err := s.SanitizeStream(context.Background(), strings.NewReader("电话13812345678"), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "电话138****5678" || result.Stats.ByCategory["phone"] != 1 {
+		t.Errorf("unexpected stream result: %q %+v", out.String(), result.Stats)
+	}
+}
+
+func TestNewInvalidOption(t *testing.T) {
+	_, err := sanitize.New(sanitize.WithStrategy("blur"))
+	var reqErr *types.RequestError
+	if !errors.As(err, &reqErr) || reqErr.Code != types.ErrCodeInvalidStrategy {
+		t.Errorf("expected INVALID_STRATEGY, got %v", err)
+	}
+
+	_, err = sanitize.New(sanitize.WithCategories("employee_id"))
+	if !errors.As(err, &reqErr) || reqErr.Code != types.ErrCodeUnknownCategory {
+		t.Errorf("expected UNKNOWN_CATEGORY, got %v", err)
+	}
+
+	_, err = sanitize.New(sanitize.WithCorporateRanges("10.0.0.0/33"))
+	if !errors.As(err, &reqErr) || reqErr.Code != types.ErrCodeInvalidIPRange {
+		t.Errorf("expected INVALID_IP_RANGE, got %v", err)
+	}
+
+	_, err = sanitize.New(sanitize.WithPhoneRegions("XX"))
+	if !errors.As(err, &reqErr) || reqErr.Code != types.ErrCodeInvalidPhoneRegion {
+		t.Errorf("expected INVALID_PHONE_REGION, got %v", err)
+	}
+}
+
+func TestCancelledContext(t *testing.T) {
+	s, err := sanitize.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Sanitize(ctx, "13812345678"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func ExampleNew() {
+	s, err := sanitize.New(
+		sanitize.WithStrategy(sanitize.StrategyMask),
+		sanitize.WithCategories("phone", "email"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	result, err := s.Sanitize(context.Background(), "我的手机号是13812345678")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(result.SanitizedText)
+	// Output: 我的手机号是138****5678
+}
@@ -3,25 +3,57 @@ package types
 // Request 表示清洗请求
 type Request struct {
 	Text              string   `json:"text"`
-	Mode              string   `json:"mode"`               // "annotate" | "sanitize"
-	Strategy          string   `json:"strategy"`           // "mask" | "redact" | "pseudonym"
-	Level             string   `json:"level"`              // "lenient" | "standard" | "strict"
-	EnabledCategories []string `json:"enabled_categories"` // 启用的类别列表
-	Allowlist         []string `json:"allowlist"`          // 白名单字符串列表
-	SemanticMode      string   `json:"semantic_mode"`      // "off" | "on" (预留，默认 off)
+	Mode              string   `json:"mode"`                       // "annotate" | "sanitize"
+	Strategy          string   `json:"strategy"`                   // "mask" | "redact" | "pseudonym"
+	Level             string   `json:"level"`                      // "lenient" | "standard" | "strict"
+	EnabledCategories []string `json:"enabled_categories"`         // 启用的类别列表
+	Allowlist         []string `json:"allowlist"`                  // 白名单字符串列表
+	SemanticMode      string   `json:"semantic_mode"`              // "off" | "on" (预留，默认 off)
+	Profile           string   `json:"profile,omitempty"`          // 使用的命名配置，请求中填写的字段覆盖配置中的值
+	TimeoutMs         int      `json:"timeout_ms,omitempty"`       // 处理时间预算（毫秒），只能比引擎的预算更短
+	Locale            string   `json:"locale,omitempty"`           // 区域设置（BCP 47，如 en-US、en-GB），用于区分 MM/DD 与 DD/MM 等格式
+	ContextualDates   bool     `json:"contextual_dates,omitempty"` // 识别姓名、证件号等身份信息附近未带关键词的日期
+}
+
+// Profile 表示配置文件中的一个命名配置，字段含义与 Request 相同
+type Profile struct {
+	Mode              string   `json:"mode,omitempty"`
+	Strategy          string   `json:"strategy,omitempty"`
+	Level             string   `json:"level,omitempty"`
+	EnabledCategories []string `json:"enabled_categories,omitempty"`
+	Allowlist         []string `json:"allowlist,omitempty"`
+	Locale            string   `json:"locale,omitempty"`
+	ContextualDates   bool     `json:"contextual_dates,omitempty"`
 }
 
 // Finding 表示一个识别到的敏感信息
 type Finding struct {
-	Type               string  `json:"type"`                // 类别：phone, email, id_card, ip, domain, token, password, private_key
-	Start              int     `json:"start"`               // 原文中的起始位置
-	End                int     `json:"end"`                 // 原文中的结束位置
-	Confidence         float64 `json:"confidence"`          // 置信度 0-1
-	Risk               int     `json:"risk"`                // 风险等级 0-100
-	Replacement        string  `json:"replacement"`         // 替换后的文本
-	ReplacementPreview string  `json:"replacement_preview"` // 用于报告的预览（掩码）
-	Reason             string  `json:"reason"`              // 识别原因说明
-	OriginalText       string  `json:"original_text"`       // 原始文本片段（仅用于内部，不输出到 JSON）
+	Type               string  `json:"type"`                    // 类别：phone, email, id_card, ip, domain, token, password, private_key
+	Start              int     `json:"start"`                   // 原文中的起始位置（UTF-8 字节偏移）
+	End                int     `json:"end"`                     // 原文中的结束位置（UTF-8 字节偏移）
+	RuneStart          int     `json:"rune_start"`              // 起始位置（Unicode 字符偏移）
+	RuneEnd            int     `json:"rune_end"`                // 结束位置（Unicode 字符偏移）
+	UTF16Start         int     `json:"utf16_start"`             // 起始位置（UTF-16 码元偏移，与 JavaScript 字符串下标一致）
+	UTF16End           int     `json:"utf16_end"`               // 结束位置（UTF-16 码元偏移）
+	Line               int     `json:"line"`                    // 起始行号（从 1 开始）
+	Column             int     `json:"column"`                  // 起始列号（从 1 开始，按 Unicode 字符计）
+	EndLine            int     `json:"end_line"`                // 结束行号
+	EndColumn          int     `json:"end_column"`              // 结束列号（指向最后一个字符之后）
+	Confidence         float64 `json:"confidence"`              // 置信度 0-1
+	Risk               int     `json:"risk"`                    // 风险等级 0-100
+	Replacement        string  `json:"replacement"`             // 替换后的文本
+	ReplacementPreview string  `json:"replacement_preview"`     // 用于报告的预览（掩码）
+	Reason             string  `json:"reason"`                  // 识别原因说明
+	Valid              *bool   `json:"valid,omitempty"`         // 是否通过结构校验（校验位、日期等），不做校验的类别省略
+	Network            string  `json:"network,omitempty"`       // 卡组织：unionpay, visa, mastercard, amex, jcb, discover（仅银行卡和信用卡）
+	Precision          *int    `json:"precision,omitempty"`     // 坐标精度（度的小数位数），仅 GPS 坐标
+	IPClass            string  `json:"ip_class,omitempty"`      // 地址分类：corporate, public, private, link_local, loopback, documentation, reserved（仅 IP 地址）
+	CountryCode        string  `json:"country_code,omitempty"`  // 国际电话区号，如 86、44（仅电话号码）
+	LineType           string  `json:"line_type,omitempty"`     // 号码类型：mobile, fixed_line, fixed_line_or_mobile, toll_free（仅电话号码）
+	RuleID             string  `json:"rule_id,omitempty"`       // 命中的规则 ID，如 slack-bot-token（仅 Token/Key）
+	DocumentType       string  `json:"document_type,omitempty"` // 证件子类型：cn_id, hkid, macau_id, taiwan_id, cn_passport, passport, hk_macau_permit, taiwan_compatriot_permit, home_return_permit，境外证件见 national_id 的子类型，如 us_ssn、gb_nino（仅身份证、护照和境外证件号码）
+	Region             string  `json:"region,omitempty"`        // 签发证件的国家或地区（ISO 3166-1），如 CN、HK、US（仅身份证、护照和境外证件号码）
+	OriginalText       string  `json:"original_text"`           // 原始文本片段（仅用于内部，不输出到 JSON）
 }
 
 // Stats 表示统计信息
@@ -40,4 +72,116 @@ type Response struct {
 	Stats         Stats     `json:"stats"`
 	RiskScore     int       `json:"risk_score"` // 0-100
 	Version       string    `json:"version"`
+	Warnings      []Warning `json:"warnings,omitempty"` // 不影响处理的请求问题，如已废弃的字段
+
+	// 处理超时时只包含已完成检测器的结果
+	Partial             bool     `json:"partial,omitempty"`
+	IncompleteDetectors []string `json:"incomplete_detectors,omitempty"` // 未完成的检测类别
+}
+
+// 错误码，随错误响应中的 code 字段返回
+const (
+	ErrCodeInvalidJSON         = "INVALID_JSON"
+	ErrCodeMissingText         = "MISSING_TEXT"
+	ErrCodeInvalidMode         = "INVALID_MODE"
+	ErrCodeInvalidStrategy     = "INVALID_STRATEGY"
+	ErrCodeInvalidLevel        = "INVALID_LEVEL"
+	ErrCodeInvalidSemanticMode = "INVALID_SEMANTIC_MODE"
+	ErrCodeUnknownCategory     = "UNKNOWN_CATEGORY"
+	ErrCodeUnknownProfile      = "UNKNOWN_PROFILE"
+	ErrCodeInvalidTimeout      = "INVALID_TIMEOUT"
+	ErrCodeInvalidLocale       = "INVALID_LOCALE"
+	ErrCodeInvalidIPRange      = "INVALID_IP_RANGE"     // 企业内网网段格式错误
+	ErrCodeInvalidPhoneRegion  = "INVALID_PHONE_REGION" // 不支持的电话号码地区
+	ErrCodeInputTooLarge       = "INPUT_TOO_LARGE"      // 文本超过引擎的大小预算
+	ErrCodeRequestTooLarge     = "REQUEST_TOO_LARGE"
+	ErrCodeNotFound            = "NOT_FOUND"
+	ErrCodeMethodNotAllowed    = "METHOD_NOT_ALLOWED"
+	ErrCodeInvalidMessage      = "INVALID_MESSAGE" // --serve 模式下消息格式错误
+	ErrCodeProcessingError     = "PROCESSING_ERROR"
+	ErrCodeInternalError       = "INTERNAL_ERROR"
+)
+
+// 警告码，随响应中的 warnings 字段返回
+const (
+	WarnCodeDeprecatedField   = "DEPRECATED_FIELD"
+	WarnCodeDuplicateCategory = "DUPLICATE_CATEGORY"
+)
+
+// Warning 表示请求中不影响处理的问题
+type Warning struct {
+	Code    string `json:"code"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// RequestError 表示请求校验失败
+type RequestError struct {
+	Code    string // 机器可读的错误码，如 INVALID_STRATEGY
+	Field   string // 出错的请求字段
+	Message string
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+// ErrorResponse 表示错误响应
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+	Field string `json:"field,omitempty"`
+}
+
+// ServeMessage 表示 --serve 模式下宿主发来的一行消息
+type ServeMessage struct {
+	ID      string   `json:"id"`                // 请求 ID，由宿主生成，响应中原样返回
+	Type    string   `json:"type"`              // "process" | "cancel" | "shutdown"
+	Request *Request `json:"request,omitempty"` // 仅 type 为 "process" 时需要
+}
+
+// ServeReply 表示 --serve 模式下引擎输出的一行消息
+type ServeReply struct {
+	ID       string    `json:"id,omitempty"`
+	Type     string    `json:"type"` // "ready" | "result" | "error" | "cancelled" | "shutdown"
+	Response *Response `json:"response,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Code     string    `json:"code,omitempty"` // 错误码，仅 type 为 "error" 时返回
+}
+
+// BatchError 表示批处理中某一行处理失败
+type BatchError struct {
+	Line  int    `json:"line"` // 输入中的行号（从 1 开始）
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// BatchSummary 表示批处理结束时输出的汇总信息
+type BatchSummary struct {
+	Records   int   `json:"records"`   // 处理的记录数（不含空行）
+	Succeeded int   `json:"succeeded"` // 成功的记录数
+	Failed    int   `json:"failed"`    // 失败的记录数
+	Stats     Stats `json:"stats"`     // 所有成功记录的统计信息汇总
+}
+
+// ScanFileResult 表示扫描命令中单个文件的处理结果
+type ScanFileResult struct {
+	Path       string    `json:"path"`                  // 源文件路径
+	OutputPath string    `json:"output_path,omitempty"` // 清洗后副本的路径
+	Size       int64     `json:"size"`                  // 源文件大小（字节）
+	Status     string    `json:"status"`                // "processed" | "skipped" | "error"
+	Reason     string    `json:"reason,omitempty"`      // 跳过或失败的原因
+	Findings   []Finding `json:"findings,omitempty"`
+	Stats      *Stats    `json:"stats,omitempty"`
+	RiskScore  int       `json:"risk_score"`
+}
+
+// ScanReport 表示扫描命令输出的汇总报告
+type ScanReport struct {
+	Version   string           `json:"version"`
+	Processed int              `json:"processed"`
+	Skipped   int              `json:"skipped"`
+	Failed    int              `json:"failed"`
+	Stats     Stats            `json:"stats"` // 所有已处理文件的统计信息汇总
+	Files     []ScanFileResult `json:"files"`
 }